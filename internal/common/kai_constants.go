@@ -6,7 +6,19 @@ const (
 	ConfigRunnerLoggerOutputPathsKey      = "runner.logger.output_paths"
 	ConfigRunnerLoggerErrorOutputPathsKey = "runner.logger.error_output_paths"
 	ConfigRunnerLoggerEncodingKey         = "runner.logger.encoding"
+	ConfigRunnerLoggerLevelConfigKey      = "runner.logger.level_config_key"
 	ConfigRunnerSubscriberAckWaitTimeKey  = "runner.subscriber.ack_wait_time"
+	ConfigRunnerSubscriberFetchMaxWaitKey = "runner.subscriber.fetch_max_wait"
+	ConfigRunnerSubscriberConsumersKey    = "runner.subscriber.consumers"
+	ConfigRunnerSubscriberForwardWaitKey  = "runner.subscriber.forward_wait"
+	ConfigRunnerSubscriberForwardTriesKey = "runner.subscriber.forward_tries"
+	ConfigRunnerSubscriberForwardDelayKey = "runner.subscriber.forward_delay"
+	ConfigRunnerSubscriberBatchTriesKey   = "runner.subscriber.batch_tries"
+	ConfigRunnerSubscriberBatchDelayKey   = "runner.subscriber.batch_delay"
+	ConfigRunnerSchedulerLockBucketKey    = "runner.scheduler.lock_bucket"
+	ConfigRunnerSchedulerLockTTLKey       = "runner.scheduler.lock_ttl"
+	ConfigRunnerHealthEnabledKey          = "runner.health.enabled"
+	ConfigRunnerHealthPortKey             = "runner.health.port"
 	ConfigMetadataProductIDKey            = "metadata.product_id"
 	ConfigMetadataWorkflowIDKey           = "metadata.workflow_name"
 	ConfigMetadataWorkflowTypeKey         = "metadata.workflow_type"
@@ -22,6 +34,12 @@ const (
 	ConfigCcProductBucketKey              = "centralized_configuration.product.bucket"
 	ConfigCcWorkflowBucketKey             = "centralized_configuration.workflow.bucket"
 	ConfigCcProcessBucketKey              = "centralized_configuration.process.bucket"
+	ConfigCcProcessSchemaKey              = "centralized_configuration.process.schema"
+	ConfigCcCacheEnabledKey               = "centralized_configuration.cache.enabled"
+	ConfigCcCacheTTLKey                   = "centralized_configuration.cache.ttl"
+	ConfigCcCacheMaxSizeKey               = "centralized_configuration.cache.max_size"
+	ConfigCcSecretsKeyFileKey             = "centralized_configuration.secrets.key_file"
+	ConfigCcSecretsKeyEnvKey              = "centralized_configuration.secrets.key_env"
 	ConfigMinioEndpointKey                = "minio.endpoint"
 	ConfigMinioClientUserKey              = "minio.client_user"
 	ConfigMinioClientPasswordKey          = "minio.client_password" //nolint:gosec // False positive
@@ -37,8 +55,26 @@ const (
 	ConfigRedisPasswordKey                = "predictions.password"
 	ConfigRedisIndexKey                   = "predictions.index"
 	ConfigModelFolderNameKey              = "model_registry.folder_name"
+	ConfigMeasurementsExporterKey         = "measurements.exporter"
 	ConfigMeasurementsEndpointKey         = "measurements.endpoint"
 	ConfigMeasurementsInsecureKey         = "measurements.insecure"
 	ConfigMeasurementsTimeoutKey          = "measurements.timeout"
 	ConfigMeasurementsMetricsIntervalKey  = "measurements.metrics_interval"
+	ConfigMeasurementsShutdownTimeoutKey  = "measurements.shutdown_timeout"
+	ConfigMeasurementsLogsEnabledKey      = "measurements.logs.enabled"
+	ConfigMeasurementsTracesEnabledKey    = "measurements.traces.enabled"
+)
+
+const (
+	ProcessTypeTrigger = "trigger"
+	ProcessTypeTask    = "task"
+	ProcessTypeExit    = "exit"
+)
+
+const (
+	MeasurementsExporterOtlpGrpc   = "otlp-grpc"
+	MeasurementsExporterOtlpHTTP   = "otlp-http"
+	MeasurementsExporterPrometheus = "prometheus"
+	MeasurementsExporterStdout     = "stdout"
+	MeasurementsExporterNoop       = "noop"
 )
@@ -8,7 +8,9 @@ import (
 var (
 	ErrUndefinedEphemeralStorage = errors.New("the ephemeral storage does not exist")
 	ErrMessageToBig              = errors.New("compressed message exceeds maximum size allowed")
-	ErrMsgAck                    = "Error in message ack" //nolint:gochecknoglobals // This is a constant
+	ErrMsgAck                    = "Error in message ack"  //nolint:gochecknoglobals // This is a constant
+	ErrMsgNak                    = "Error in message nak"  //nolint:gochecknoglobals // This is a constant
+	ErrMsgTerm                   = "Error in message term" //nolint:gochecknoglobals // This is a constant
 	ErrEmptyPayload              = errors.New("the payload cannot be empty")
 	ErrEmptyModel                = errors.New("the model cannot be empty")
 	ErrModelNotFound             = errors.New("the given model does not exist")
@@ -18,6 +20,9 @@ var (
 	ErrInvalidKey                = errors.New("the key is not valid")
 	ErrEmptyName                 = errors.New("the name cannot be empty")
 	ErrObjectAlreadyExists       = errors.New("object already exists for the given key")
+	ErrInvalidTTL                = errors.New("the TTL must be a positive number of days")
+	ErrInvalidContinuationToken  = errors.New("the continuation token is not valid")
+	ErrMissingReplySubject       = errors.New("the response has no reply subject to route it back to the trigger")
 )
 
 // Wrapper creates a function that returns errors starts with a given message.
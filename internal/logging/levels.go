@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var ErrInvalidLevel = errors.New("invalid logger level")
+
+// PermissiveLevel lets the wrapped cores log everything, the filtering is done by the Levels core.
+const PermissiveLevel = zapcore.Level(math.MinInt8)
+
+// Levels keeps the level of the logs and the overrides of specific loggers, which can be changed at runtime.
+type Levels struct {
+	mu        sync.RWMutex
+	initial   zapcore.Level
+	level     zapcore.Level
+	overrides map[string]zapcore.Level
+}
+
+func NewLevels(level zapcore.Level) *Levels {
+	return &Levels{
+		initial:   level,
+		level:     level,
+		overrides: make(map[string]zapcore.Level),
+	}
+}
+
+// Set parses the given value and applies it, the format is the level of every logger followed by
+// the overrides of specific loggers, e.g. "info,[MESSAGING]=debug". Levels are zap level names or
+// logr verbosities.
+func (l *Levels) Set(value string) error {
+	level := l.initial
+	overrides := make(map[string]zapcore.Level)
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, levelValue, isOverride := strings.Cut(part, "=")
+
+		parsed, err := parseLevel(levelValue)
+		if !isOverride {
+			parsed, err = parseLevel(name)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if isOverride {
+			overrides[strings.TrimSpace(name)] = parsed
+		} else {
+			level = parsed
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = level
+	l.overrides = overrides
+
+	return nil
+}
+
+// Reset restores the level set at startup and removes the overrides.
+func (l *Levels) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = l.initial
+	l.overrides = make(map[string]zapcore.Level)
+}
+
+// Level returns the level of the logs of the given logger.
+func (l *Levels) Level(loggerName string) zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	// Logger names are nested, e.g. "[TASK].[MESSAGING]", the innermost override wins.
+	names := strings.Split(loggerName, ".")
+	for i := len(names) - 1; i >= 0; i-- {
+		if level, ok := l.overrides[names[i]]; ok {
+			return level
+		}
+	}
+
+	return l.level
+}
+
+func (l *Levels) minLevel() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	minLevel := l.level
+	for _, level := range l.overrides {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+
+	return minLevel
+}
+
+// WrapCore filters the entries of the given core by the level of their logger.
+func (l *Levels) WrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return level >= c.levels.minLevel()
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < c.levels.Level(entry.LoggerName) {
+		return checked
+	}
+
+	return c.Core.Check(entry, checked)
+}
+
+func parseLevel(value string) (zapcore.Level, error) {
+	value = strings.TrimSpace(value)
+
+	// logr verbosities are negative zap levels.
+	if verbosity, err := strconv.Atoi(value); err == nil && verbosity >= 0 && verbosity <= math.MaxInt8 {
+		return zapcore.Level(-verbosity), nil
+	}
+
+	level, err := zapcore.ParseLevel(value)
+	if err != nil {
+		return level, fmt.Errorf("%w: %q", ErrInvalidLevel, value)
+	}
+
+	return level, nil
+}
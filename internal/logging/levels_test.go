@@ -0,0 +1,103 @@
+//go:build unit
+
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/konstellation-io/kai-gosdk/internal/logging"
+)
+
+type LevelsTestSuite struct {
+	suite.Suite
+	levels   *logging.Levels
+	observed *observer.ObservedLogs
+	logger   *zap.Logger
+}
+
+func (s *LevelsTestSuite) SetupTest() {
+	s.levels = logging.NewLevels(zapcore.InfoLevel)
+
+	var core zapcore.Core
+
+	core, s.observed = observer.New(logging.PermissiveLevel)
+	s.logger = zap.New(s.levels.WrapCore(core))
+}
+
+func (s *LevelsTestSuite) TestSet_WithGlobalLevel_ExpectLevelChanged() {
+	// When
+	err := s.levels.Set("debug")
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(zapcore.DebugLevel, s.levels.Level("[TASK]"))
+}
+
+func (s *LevelsTestSuite) TestSet_WithOverrides_ExpectInnermostLoggerLevel() {
+	// When
+	err := s.levels.Set("warn, [MESSAGING]=debug, [TASK]=error")
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(zapcore.WarnLevel, s.levels.Level("[EXIT]"))
+	s.Equal(zapcore.ErrorLevel, s.levels.Level("[TASK]"))
+	s.Equal(zapcore.DebugLevel, s.levels.Level("[TASK].[MESSAGING]"))
+}
+
+func (s *LevelsTestSuite) TestSet_WithVerbosity_ExpectNegativeLevel() {
+	// When
+	err := s.levels.Set("[MESSAGING]=3")
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(zapcore.Level(-3), s.levels.Level("[MESSAGING]"))
+	s.Equal(zapcore.InfoLevel, s.levels.Level("[TASK]"))
+}
+
+func (s *LevelsTestSuite) TestSet_WithInvalidLevel_ExpectErrorAndLevelsKept() {
+	// Given
+	s.Require().NoError(s.levels.Set("debug"))
+
+	// When
+	err := s.levels.Set("info,[MESSAGING]=verbose")
+
+	// Then
+	s.ErrorIs(err, logging.ErrInvalidLevel)
+	s.Equal(zapcore.DebugLevel, s.levels.Level("[MESSAGING]"))
+}
+
+func (s *LevelsTestSuite) TestReset_ExpectStartupLevel() {
+	// Given
+	s.Require().NoError(s.levels.Set("debug,[MESSAGING]=error"))
+
+	// When
+	s.levels.Reset()
+
+	// Then
+	s.Equal(zapcore.InfoLevel, s.levels.Level("[MESSAGING]"))
+}
+
+func (s *LevelsTestSuite) TestWrapCore_ExpectEntriesFilteredByLogger() {
+	// Given
+	s.Require().NoError(s.levels.Set("info,[MESSAGING]=debug"))
+
+	// When
+	s.logger.Named("[TASK]").Debug("task debug")
+	s.logger.Named("[TASK]").Named("[MESSAGING]").Debug("messaging debug")
+	s.logger.Named("[TASK]").Info("task info")
+
+	// Then
+	entries := s.observed.All()
+	s.Require().Len(entries, 2)
+	s.Equal("messaging debug", entries[0].Message)
+	s.Equal("task info", entries[1].Message)
+}
+
+func TestLevelsTestSuite(t *testing.T) {
+	suite.Run(t, new(LevelsTestSuite))
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+)
+
+const (
+	MeterName = "measurements"
+
+	_directionIn  = "in"
+	_directionOut = "out"
+)
+
+type processMetadata interface {
+	GetProduct() string
+	GetVersion() string
+	GetWorkflow() string
+	GetProcess() string
+}
+
+// Runner holds the standard instruments recorded while receiving, processing and publishing messages.
+// Request ids are not used as attributes to keep the cardinality bounded, they are logged instead.
+type Runner struct {
+	ProcessingTime    metric.Int64Histogram
+	MessagesReceived  metric.Int64Counter
+	MessagesProcessed metric.Int64Counter
+	MessagesFailed    metric.Int64Counter
+	MessagesInFlight  metric.Int64UpDownCounter
+	PayloadSize       metric.Int64Histogram
+	CompressedSize    metric.Int64Histogram
+	PublishLatency    metric.Int64Histogram
+	AckFailures       metric.Int64Counter
+	Redeliveries      metric.Int64Counter
+}
+
+func NewRunner(meter metric.Meter) (*Runner, error) {
+	var (
+		m    Runner
+		errs [10]error
+	)
+
+	m.ProcessingTime, errs[0] = meter.Int64Histogram("runner-process-message-metric",
+		metric.WithDescription("How long it takes to process a message and times called."),
+		metric.WithUnit("ms"))
+	m.MessagesReceived, errs[1] = meter.Int64Counter("runner-messages-received",
+		metric.WithDescription("Number of messages received."))
+	m.MessagesProcessed, errs[2] = meter.Int64Counter("runner-messages-processed",
+		metric.WithDescription("Number of messages processed successfully."))
+	m.MessagesFailed, errs[3] = meter.Int64Counter("runner-messages-failed",
+		metric.WithDescription("Number of messages whose processing failed."))
+	m.MessagesInFlight, errs[4] = meter.Int64UpDownCounter("runner-messages-in-flight",
+		metric.WithDescription("Number of messages being processed."))
+	m.PayloadSize, errs[5] = meter.Int64Histogram("runner-message-size",
+		metric.WithDescription("Uncompressed size of the messages received and published."),
+		metric.WithUnit("By"))
+	m.CompressedSize, errs[6] = meter.Int64Histogram("runner-message-compressed-size",
+		metric.WithDescription("Compressed size of the messages received and published."),
+		metric.WithUnit("By"))
+	m.PublishLatency, errs[7] = meter.Int64Histogram("runner-publish-latency",
+		metric.WithDescription("How long it takes to publish a message."),
+		metric.WithUnit("ms"))
+	m.AckFailures, errs[8] = meter.Int64Counter("runner-ack-failures",
+		metric.WithDescription("Number of messages that could not be acknowledged."))
+	m.Redeliveries, errs[9] = meter.Int64Counter("runner-message-redeliveries",
+		metric.WithDescription("Number of messages received more than once."))
+
+	err := errors.Join(errs[:]...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Attributes returns the attributes of the measurements of messages coming from the given subject and node.
+func Attributes(meta processMetadata, subject, fromNode string) metric.MeasurementOption {
+	return metric.WithAttributeSet(attribute.NewSet(
+		attribute.String("product", meta.GetProduct()),
+		attribute.String("version", meta.GetVersion()),
+		attribute.String("workflow", meta.GetWorkflow()),
+		attribute.String("process", meta.GetProcess()),
+		attribute.String("subject", subject),
+		attribute.String("from_node", fromNode),
+	))
+}
+
+// RecordReceived records a received message, its sizes and whether it is a redelivery.
+func (m *Runner) RecordReceived(ctx context.Context, msg *nats.Msg, requestMsg *kai.KaiNatsMessage,
+	attrs metric.MeasurementOption,
+) {
+	m.MessagesReceived.Add(ctx, 1, attrs)
+
+	if requestMsg != nil {
+		m.recordSize(ctx, int64(proto.Size(requestMsg)), msg.Data, _directionIn, attrs)
+	}
+
+	if meta, err := msg.Metadata(); err == nil && meta.NumDelivered > 1 {
+		m.Redeliveries.Add(ctx, 1, attrs)
+	}
+}
+
+// RecordPublished records the sizes of a published message, given its marshaled and prepared data.
+func (m *Runner) RecordPublished(ctx context.Context, size int64, data []byte, attrs metric.MeasurementOption) {
+	m.recordSize(ctx, size, data, _directionOut, attrs)
+}
+
+func (m *Runner) recordSize(ctx context.Context, size int64, data []byte, direction string,
+	attrs metric.MeasurementOption,
+) {
+	directionAttr := metric.WithAttributes(attribute.String("direction", direction))
+
+	m.PayloadSize.Record(ctx, size, attrs, directionAttr)
+
+	if common.IsCompressed(data) {
+		m.CompressedSize.Record(ctx, int64(len(data)), attrs, directionAttr)
+	}
+}
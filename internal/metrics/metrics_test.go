@@ -0,0 +1,118 @@
+//go:build unit
+
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/suite"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+)
+
+type testMetadata struct{}
+
+func (testMetadata) GetProduct() string  { return "product" }
+func (testMetadata) GetVersion() string  { return "v1" }
+func (testMetadata) GetWorkflow() string { return "workflow" }
+func (testMetadata) GetProcess() string  { return "process" }
+
+type RunnerMetricsTestSuite struct {
+	suite.Suite
+	reader  *sdkMetric.ManualReader
+	metrics *metrics.Runner
+}
+
+func (s *RunnerMetricsTestSuite) SetupTest() {
+	s.reader = sdkMetric.NewManualReader()
+	provider := sdkMetric.NewMeterProvider(sdkMetric.WithReader(s.reader))
+
+	var err error
+
+	s.metrics, err = metrics.NewRunner(provider.Meter(metrics.MeterName))
+	s.Require().NoError(err)
+}
+
+func (s *RunnerMetricsTestSuite) collect() map[string]metricdata.Metrics {
+	var data metricdata.ResourceMetrics
+
+	s.Require().NoError(s.reader.Collect(context.Background(), &data))
+
+	collected := make(map[string]metricdata.Metrics)
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			collected[m.Name] = m
+		}
+	}
+
+	return collected
+}
+
+func (s *RunnerMetricsTestSuite) TestRecordReceived_ExpectCounterAndSize() {
+	// Given
+	requestMsg := &kai.KaiNatsMessage{RequestId: "123", FromNode: "parent-node"}
+	data, err := proto.Marshal(requestMsg)
+	s.Require().NoError(err)
+
+	msg := &nats.Msg{Subject: "test-subject", Data: data}
+
+	// When
+	s.metrics.RecordReceived(context.Background(), msg, requestMsg,
+		metrics.Attributes(testMetadata{}, msg.Subject, requestMsg.GetFromNode()))
+
+	// Then
+	collected := s.collect()
+
+	received, ok := collected["runner-messages-received"].Data.(metricdata.Sum[int64])
+	s.Require().True(ok)
+	s.Require().Len(received.DataPoints, 1)
+	s.Equal(int64(1), received.DataPoints[0].Value)
+
+	attrs := received.DataPoints[0].Attributes
+	subject, _ := attrs.Value("subject")
+	fromNode, _ := attrs.Value("from_node")
+	s.Equal("test-subject", subject.AsString())
+	s.Equal("parent-node", fromNode.AsString())
+	s.False(attrs.HasValue("request_id"))
+
+	s.Contains(collected, "runner-message-size")
+	s.NotContains(collected, "runner-message-compressed-size")
+	s.NotContains(collected, "runner-message-redeliveries")
+}
+
+func (s *RunnerMetricsTestSuite) TestRecordPublished_WithCompressedData_ExpectCompressedSize() {
+	// Given
+	requestMsg := &kai.KaiNatsMessage{RequestId: "123", FromNode: "process"}
+	data, err := proto.Marshal(requestMsg)
+	s.Require().NoError(err)
+
+	compressed, err := common.CompressData(data)
+	s.Require().NoError(err)
+
+	// When
+	s.metrics.RecordPublished(context.Background(), int64(len(data)), compressed,
+		metrics.Attributes(testMetadata{}, "output-subject", "process"))
+
+	// Then
+	collected := s.collect()
+
+	size, ok := collected["runner-message-compressed-size"].Data.(metricdata.Histogram[int64])
+	s.Require().True(ok)
+	s.Require().Len(size.DataPoints, 1)
+	s.Equal(uint64(1), size.DataPoints[0].Count)
+
+	direction, _ := size.DataPoints[0].Attributes.Value("direction")
+	s.Equal("out", direction.AsString())
+}
+
+func TestRunnerMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(RunnerMetricsTestSuite))
+}
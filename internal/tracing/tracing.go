@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope of the spans started by the SDK.
+const TracerName = "github.com/konstellation-io/kai-gosdk"
+
+// headerCarrier adapts the NATS message headers to the OpenTelemetry propagators.
+type headerCarrier nats.Header
+
+func (c headerCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// StartSpan starts a consumer span processing the message, child of the trace propagated in its W3C traceparent
+// header. The span is created with the global tracer provider, so unless one is configured it is a non-recording
+// span that carries the propagated trace, if any. The span must be ended with EndSpan.
+func StartSpan(ctx context.Context, msg *nats.Msg) context.Context {
+	if msg == nil {
+		return ctx
+	}
+
+	if msg.Header != nil {
+		ctx = propagation.TraceContext{}.Extract(ctx, headerCarrier(msg.Header))
+	}
+
+	ctx, _ = otel.Tracer(TracerName).Start(ctx, fmt.Sprintf("%s process", msg.Subject),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	)
+
+	return ctx
+}
+
+// StartProducerSpan starts a producer span sending a message to the subject, child of the span in the context or
+// the root of a new trace if there is none. The span must be ended with EndSpan.
+func StartProducerSpan(ctx context.Context, subject string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, _ = otel.Tracer(TracerName).Start(ctx, fmt.Sprintf("%s send", subject),
+		trace.WithSpanKind(trace.SpanKindProducer),
+	)
+
+	return ctx
+}
+
+// EndSpan ends the span in the context, if any.
+func EndSpan(ctx context.Context) {
+	trace.SpanFromContext(ctx).End()
+}
+
+// InjectHeader sets in the given headers the W3C traceparent of the span in the context, if any.
+// It returns whether the headers were set.
+func InjectHeader(ctx context.Context, header nats.Header) bool {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return false
+	}
+
+	propagation.TraceContext{}.Inject(ctx, headerCarrier(header))
+
+	return true
+}
+
+// IDs returns the trace and span ids of the span in the context, empty if there is none.
+func IDs(ctx context.Context) (traceID, spanID string) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return "", ""
+	}
+
+	return spanContext.TraceID().String(), spanContext.SpanID().String()
+}
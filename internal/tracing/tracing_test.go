@@ -0,0 +1,185 @@
+//go:build unit
+
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/konstellation-io/kai-gosdk/internal/tracing"
+)
+
+const (
+	_traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	_traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	_spanID      = "00f067aa0ba902b7"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(s.recorder)))
+}
+
+func (s *TracingTestSuite) TearDownSuite() {
+	otel.SetTracerProvider(noop.NewTracerProvider())
+}
+
+func (s *TracingTestSuite) TestStartSpan_WithTraceparent_ExpectChildSpanExported() {
+	// Given
+	msg := nats.NewMsg("test-subject")
+	msg.Header.Set("traceparent", _traceparent)
+
+	// When
+	ctx := tracing.StartSpan(context.Background(), msg)
+	tracing.EndSpan(ctx)
+
+	// Then
+	traceID, spanID := tracing.IDs(ctx)
+	s.Equal(_traceID, traceID)
+	s.NotEqual(_spanID, spanID)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal("test-subject process", spans[0].Name())
+	s.Equal(trace.SpanKindConsumer, spans[0].SpanKind())
+	s.Equal(spanID, spans[0].SpanContext().SpanID().String())
+	s.Equal(_spanID, spans[0].Parent().SpanID().String())
+}
+
+func (s *TracingTestSuite) TestStartSpan_WithoutTraceparent_ExpectNewTrace() {
+	// Given
+	msg := &nats.Msg{Subject: "test-subject"}
+
+	// When
+	ctx := tracing.StartSpan(context.Background(), msg)
+	tracing.EndSpan(ctx)
+
+	// Then
+	traceID, spanID := tracing.IDs(ctx)
+	s.Len(traceID, 32)
+	s.Len(spanID, 16)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.False(spans[0].Parent().IsValid())
+}
+
+func (s *TracingTestSuite) TestStartSpan_WithoutTracerProvider_ExpectParentSpanKept() {
+	// Given
+	otel.SetTracerProvider(noop.NewTracerProvider())
+
+	msg := nats.NewMsg("test-subject")
+	msg.Header.Set("traceparent", _traceparent)
+
+	// When
+	ctx := tracing.StartSpan(context.Background(), msg)
+
+	// Then
+	traceID, spanID := tracing.IDs(ctx)
+	s.Equal(_traceID, traceID)
+	s.Equal(_spanID, spanID)
+}
+
+func (s *TracingTestSuite) TestStartSpan_WithoutTracerProviderNorTraceparent_ExpectNoTrace() {
+	// Given
+	otel.SetTracerProvider(noop.NewTracerProvider())
+
+	msg := &nats.Msg{Subject: "test-subject"}
+
+	// When
+	ctx := tracing.StartSpan(context.Background(), msg)
+
+	// Then
+	traceID, spanID := tracing.IDs(ctx)
+	s.Empty(traceID)
+	s.Empty(spanID)
+}
+
+func (s *TracingTestSuite) TestStartProducerSpan_WithoutParent_ExpectRootSpan() {
+	// When
+	ctx := tracing.StartProducerSpan(context.Background(), "test-subject")
+	tracing.EndSpan(ctx)
+
+	// Then
+	traceID, spanID := tracing.IDs(ctx)
+	s.Len(traceID, 32)
+	s.Len(spanID, 16)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal("test-subject send", spans[0].Name())
+	s.Equal(trace.SpanKindProducer, spans[0].SpanKind())
+	s.False(spans[0].Parent().IsValid())
+}
+
+func (s *TracingTestSuite) TestStartProducerSpan_WithParent_ExpectChildSpan() {
+	// Given
+	received := nats.NewMsg("test-subject")
+	received.Header.Set("traceparent", _traceparent)
+
+	parent := tracing.StartSpan(context.Background(), received)
+
+	// When
+	ctx := tracing.StartProducerSpan(parent, "test-output")
+	tracing.EndSpan(ctx)
+
+	// Then
+	traceID, _ := tracing.IDs(ctx)
+	_, parentSpanID := tracing.IDs(parent)
+	s.Equal(_traceID, traceID)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal(parentSpanID, spans[0].Parent().SpanID().String())
+}
+
+func (s *TracingTestSuite) TestInjectHeader_ExpectCurrentSpanPropagated() {
+	// Given
+	received := nats.NewMsg("test-subject")
+	received.Header.Set("traceparent", _traceparent)
+
+	ctx := tracing.StartSpan(context.Background(), received)
+	header := nats.Header{}
+
+	// When
+	injected := tracing.InjectHeader(ctx, header)
+
+	// Then
+	s.True(injected)
+
+	traceID, spanID := tracing.IDs(ctx)
+	s.Equal("00-"+traceID+"-"+spanID+"-01", header.Get("traceparent"))
+}
+
+func (s *TracingTestSuite) TestInjectHeader_WithoutTraceContext_ExpectNoHeader() {
+	// Given
+	header := nats.Header{}
+
+	// When
+	injected := tracing.InjectHeader(context.Background(), header)
+
+	// Then
+	s.False(injected)
+	s.Empty(header)
+
+	traceID, spanID := tracing.IDs(context.Background())
+	s.Empty(traceID)
+	s.Empty(spanID)
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
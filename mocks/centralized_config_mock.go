@@ -3,8 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	centralizedconfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // CentralizedConfigMock is an autogenerated mock type for the centralizedConfig type
@@ -20,17 +25,71 @@ func (_m *CentralizedConfigMock) EXPECT() *CentralizedConfigMock_Expecter {
 	return &CentralizedConfigMock_Expecter{mock: &_m.Mock}
 }
 
+// Bind provides a mock function with given fields: ctx, target
+func (_m *CentralizedConfigMock) Bind(ctx context.Context, target interface{}) error {
+	ret := _m.Called(ctx, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bind")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CentralizedConfigMock_Bind_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bind'
+type CentralizedConfigMock_Bind_Call struct {
+	*mock.Call
+}
+
+// Bind is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target interface{}
+func (_e *CentralizedConfigMock_Expecter) Bind(ctx interface{}, target interface{}) *CentralizedConfigMock_Bind_Call {
+	return &CentralizedConfigMock_Bind_Call{Call: _e.mock.On("Bind", ctx, target)}
+}
+
+func (_c *CentralizedConfigMock_Bind_Call) Run(run func(ctx context.Context, target interface{})) *CentralizedConfigMock_Bind_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_Bind_Call) Return(_a0 error) *CentralizedConfigMock_Bind_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_Bind_Call) RunAndReturn(run func(context.Context, interface{}) error) *CentralizedConfigMock_Bind_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteConfig provides a mock function with given fields: key, scope
-func (_m *CentralizedConfigMock) DeleteConfig(key string, scope centralizedconfiguration.Scope) error {
-	ret := _m.Called(key, scope)
+func (_m *CentralizedConfigMock) DeleteConfig(key string, scope ...centralizedconfiguration.Scope) error {
+	_va := make([]interface{}, len(scope))
+	for _i := range scope {
+		_va[_i] = scope[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteConfig")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, centralizedconfiguration.Scope) error); ok {
-		r0 = rf(key, scope)
+	if rf, ok := ret.Get(0).(func(string, ...centralizedconfiguration.Scope) error); ok {
+		r0 = rf(key, scope...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,14 +104,21 @@ type CentralizedConfigMock_DeleteConfig_Call struct {
 
 // DeleteConfig is a helper method to define mock.On call
 //   - key string
-//   - scope centralizedconfiguration.Scope
-func (_e *CentralizedConfigMock_Expecter) DeleteConfig(key interface{}, scope interface{}) *CentralizedConfigMock_DeleteConfig_Call {
-	return &CentralizedConfigMock_DeleteConfig_Call{Call: _e.mock.On("DeleteConfig", key, scope)}
+//   - scope ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) DeleteConfig(key interface{}, scope ...interface{}) *CentralizedConfigMock_DeleteConfig_Call {
+	return &CentralizedConfigMock_DeleteConfig_Call{Call: _e.mock.On("DeleteConfig",
+		append([]interface{}{key}, scope...)...)}
 }
 
-func (_c *CentralizedConfigMock_DeleteConfig_Call) Run(run func(key string, scope centralizedconfiguration.Scope)) *CentralizedConfigMock_DeleteConfig_Call {
+func (_c *CentralizedConfigMock_DeleteConfig_Call) Run(run func(key string, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_DeleteConfig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(centralizedconfiguration.Scope))
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
 	})
 	return _c
 }
@@ -62,7 +128,79 @@ func (_c *CentralizedConfigMock_DeleteConfig_Call) Return(_a0 error) *Centralize
 	return _c
 }
 
-func (_c *CentralizedConfigMock_DeleteConfig_Call) RunAndReturn(run func(string, centralizedconfiguration.Scope) error) *CentralizedConfigMock_DeleteConfig_Call {
+func (_c *CentralizedConfigMock_DeleteConfig_Call) RunAndReturn(run func(string, ...centralizedconfiguration.Scope) error) *CentralizedConfigMock_DeleteConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBool provides a mock function with given fields: key, defaultValue, scopeOpt
+func (_m *CentralizedConfigMock) GetBool(key string, defaultValue bool, scopeOpt ...centralizedconfiguration.Scope) (bool, error) {
+	_va := make([]interface{}, len(scopeOpt))
+	for _i := range scopeOpt {
+		_va[_i] = scopeOpt[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, defaultValue)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBool")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, bool, ...centralizedconfiguration.Scope) (bool, error)); ok {
+		return rf(key, defaultValue, scopeOpt...)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool, ...centralizedconfiguration.Scope) bool); ok {
+		r0 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, bool, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_GetBool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBool'
+type CentralizedConfigMock_GetBool_Call struct {
+	*mock.Call
+}
+
+// GetBool is a helper method to define mock.On call
+//   - key string
+//   - defaultValue bool
+//   - scopeOpt ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) GetBool(key interface{}, defaultValue interface{}, scopeOpt ...interface{}) *CentralizedConfigMock_GetBool_Call {
+	return &CentralizedConfigMock_GetBool_Call{Call: _e.mock.On("GetBool",
+		append([]interface{}{key, defaultValue}, scopeOpt...)...)}
+}
+
+func (_c *CentralizedConfigMock_GetBool_Call) Run(run func(key string, defaultValue bool, scopeOpt ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetBool_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(bool), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetBool_Call) Return(_a0 bool, _a1 error) *CentralizedConfigMock_GetBool_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetBool_Call) RunAndReturn(run func(string, bool, ...centralizedconfiguration.Scope) (bool, error)) *CentralizedConfigMock_GetBool_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -138,46 +276,200 @@ func (_c *CentralizedConfigMock_GetConfig_Call) RunAndReturn(run func(string, ..
 	return _c
 }
 
-// SetConfig provides a mock function with given fields: key, value, scope
-func (_m *CentralizedConfigMock) SetConfig(key string, value string, scope ...centralizedconfiguration.Scope) error {
+// GetConfigHistory provides a mock function with given fields: key, scope
+func (_m *CentralizedConfigMock) GetConfigHistory(key string, scope ...centralizedconfiguration.Scope) ([]centralizedconfiguration.ConfigRevision, error) {
 	_va := make([]interface{}, len(scope))
 	for _i := range scope {
 		_va[_i] = scope[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, key, value)
+	_ca = append(_ca, key)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetConfig")
+		panic("no return value specified for GetConfigHistory")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, ...centralizedconfiguration.Scope) error); ok {
-		r0 = rf(key, value, scope...)
+	var r0 []centralizedconfiguration.ConfigRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...centralizedconfiguration.Scope) ([]centralizedconfiguration.ConfigRevision, error)); ok {
+		return rf(key, scope...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...centralizedconfiguration.Scope) []centralizedconfiguration.ConfigRevision); ok {
+		r0 = rf(key, scope...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]centralizedconfiguration.ConfigRevision)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, scope...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// CentralizedConfigMock_SetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetConfig'
-type CentralizedConfigMock_SetConfig_Call struct {
+// CentralizedConfigMock_GetConfigHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfigHistory'
+type CentralizedConfigMock_GetConfigHistory_Call struct {
 	*mock.Call
 }
 
-// SetConfig is a helper method to define mock.On call
+// GetConfigHistory is a helper method to define mock.On call
 //   - key string
-//   - value string
 //   - scope ...centralizedconfiguration.Scope
-func (_e *CentralizedConfigMock_Expecter) SetConfig(key interface{}, value interface{}, scope ...interface{}) *CentralizedConfigMock_SetConfig_Call {
-	return &CentralizedConfigMock_SetConfig_Call{Call: _e.mock.On("SetConfig",
-		append([]interface{}{key, value}, scope...)...)}
+func (_e *CentralizedConfigMock_Expecter) GetConfigHistory(key interface{}, scope ...interface{}) *CentralizedConfigMock_GetConfigHistory_Call {
+	return &CentralizedConfigMock_GetConfigHistory_Call{Call: _e.mock.On("GetConfigHistory",
+		append([]interface{}{key}, scope...)...)}
 }
 
-func (_c *CentralizedConfigMock_SetConfig_Call) Run(run func(key string, value string, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_SetConfig_Call {
+func (_c *CentralizedConfigMock_GetConfigHistory_Call) Run(run func(key string, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetConfigHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetConfigHistory_Call) Return(_a0 []centralizedconfiguration.ConfigRevision, _a1 error) *CentralizedConfigMock_GetConfigHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetConfigHistory_Call) RunAndReturn(run func(string, ...centralizedconfiguration.Scope) ([]centralizedconfiguration.ConfigRevision, error)) *CentralizedConfigMock_GetConfigHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConfigRevision provides a mock function with given fields: key, scope
+func (_m *CentralizedConfigMock) GetConfigRevision(key string, scope ...centralizedconfiguration.Scope) (centralizedconfiguration.ConfigRevision, error) {
+	_va := make([]interface{}, len(scope))
+	for _i := range scope {
+		_va[_i] = scope[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigRevision")
+	}
+
+	var r0 centralizedconfiguration.ConfigRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...centralizedconfiguration.Scope) (centralizedconfiguration.ConfigRevision, error)); ok {
+		return rf(key, scope...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...centralizedconfiguration.Scope) centralizedconfiguration.ConfigRevision); ok {
+		r0 = rf(key, scope...)
+	} else {
+		r0 = ret.Get(0).(centralizedconfiguration.ConfigRevision)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, scope...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_GetConfigRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfigRevision'
+type CentralizedConfigMock_GetConfigRevision_Call struct {
+	*mock.Call
+}
+
+// GetConfigRevision is a helper method to define mock.On call
+//   - key string
+//   - scope ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) GetConfigRevision(key interface{}, scope ...interface{}) *CentralizedConfigMock_GetConfigRevision_Call {
+	return &CentralizedConfigMock_GetConfigRevision_Call{Call: _e.mock.On("GetConfigRevision",
+		append([]interface{}{key}, scope...)...)}
+}
+
+func (_c *CentralizedConfigMock_GetConfigRevision_Call) Run(run func(key string, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetConfigRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetConfigRevision_Call) Return(_a0 centralizedconfiguration.ConfigRevision, _a1 error) *CentralizedConfigMock_GetConfigRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetConfigRevision_Call) RunAndReturn(run func(string, ...centralizedconfiguration.Scope) (centralizedconfiguration.ConfigRevision, error)) *CentralizedConfigMock_GetConfigRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDuration provides a mock function with given fields: key, defaultValue, scopeOpt
+func (_m *CentralizedConfigMock) GetDuration(key string, defaultValue time.Duration, scopeOpt ...centralizedconfiguration.Scope) (time.Duration, error) {
+	_va := make([]interface{}, len(scopeOpt))
+	for _i := range scopeOpt {
+		_va[_i] = scopeOpt[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, defaultValue)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuration")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration, ...centralizedconfiguration.Scope) (time.Duration, error)); ok {
+		return rf(key, defaultValue, scopeOpt...)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration, ...centralizedconfiguration.Scope) time.Duration); ok {
+		r0 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_GetDuration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDuration'
+type CentralizedConfigMock_GetDuration_Call struct {
+	*mock.Call
+}
+
+// GetDuration is a helper method to define mock.On call
+//   - key string
+//   - defaultValue time.Duration
+//   - scopeOpt ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) GetDuration(key interface{}, defaultValue interface{}, scopeOpt ...interface{}) *CentralizedConfigMock_GetDuration_Call {
+	return &CentralizedConfigMock_GetDuration_Call{Call: _e.mock.On("GetDuration",
+		append([]interface{}{key, defaultValue}, scopeOpt...)...)}
+}
+
+func (_c *CentralizedConfigMock_GetDuration_Call) Run(run func(key string, defaultValue time.Duration, scopeOpt ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetDuration_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
 		for i, a := range args[2:] {
@@ -185,17 +477,808 @@ func (_c *CentralizedConfigMock_SetConfig_Call) Run(run func(key string, value s
 				variadicArgs[i] = a.(centralizedconfiguration.Scope)
 			}
 		}
-		run(args[0].(string), args[1].(string), variadicArgs...)
+		run(args[0].(string), args[1].(time.Duration), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *CentralizedConfigMock_SetConfig_Call) Return(_a0 error) *CentralizedConfigMock_SetConfig_Call {
-	_c.Call.Return(_a0)
+func (_c *CentralizedConfigMock_GetDuration_Call) Return(_a0 time.Duration, _a1 error) *CentralizedConfigMock_GetDuration_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *CentralizedConfigMock_SetConfig_Call) RunAndReturn(run func(string, string, ...centralizedconfiguration.Scope) error) *CentralizedConfigMock_SetConfig_Call {
+func (_c *CentralizedConfigMock_GetDuration_Call) RunAndReturn(run func(string, time.Duration, ...centralizedconfiguration.Scope) (time.Duration, error)) *CentralizedConfigMock_GetDuration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFloat provides a mock function with given fields: key, defaultValue, scopeOpt
+func (_m *CentralizedConfigMock) GetFloat(key string, defaultValue float64, scopeOpt ...centralizedconfiguration.Scope) (float64, error) {
+	_va := make([]interface{}, len(scopeOpt))
+	for _i := range scopeOpt {
+		_va[_i] = scopeOpt[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, defaultValue)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFloat")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, float64, ...centralizedconfiguration.Scope) (float64, error)); ok {
+		return rf(key, defaultValue, scopeOpt...)
+	}
+	if rf, ok := ret.Get(0).(func(string, float64, ...centralizedconfiguration.Scope) float64); ok {
+		r0 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, float64, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_GetFloat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFloat'
+type CentralizedConfigMock_GetFloat_Call struct {
+	*mock.Call
+}
+
+// GetFloat is a helper method to define mock.On call
+//   - key string
+//   - defaultValue float64
+//   - scopeOpt ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) GetFloat(key interface{}, defaultValue interface{}, scopeOpt ...interface{}) *CentralizedConfigMock_GetFloat_Call {
+	return &CentralizedConfigMock_GetFloat_Call{Call: _e.mock.On("GetFloat",
+		append([]interface{}{key, defaultValue}, scopeOpt...)...)}
+}
+
+func (_c *CentralizedConfigMock_GetFloat_Call) Run(run func(key string, defaultValue float64, scopeOpt ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetFloat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(float64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetFloat_Call) Return(_a0 float64, _a1 error) *CentralizedConfigMock_GetFloat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetFloat_Call) RunAndReturn(run func(string, float64, ...centralizedconfiguration.Scope) (float64, error)) *CentralizedConfigMock_GetFloat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInt provides a mock function with given fields: key, defaultValue, scopeOpt
+func (_m *CentralizedConfigMock) GetInt(key string, defaultValue int, scopeOpt ...centralizedconfiguration.Scope) (int, error) {
+	_va := make([]interface{}, len(scopeOpt))
+	for _i := range scopeOpt {
+		_va[_i] = scopeOpt[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, defaultValue)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, ...centralizedconfiguration.Scope) (int, error)); ok {
+		return rf(key, defaultValue, scopeOpt...)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, ...centralizedconfiguration.Scope) int); ok {
+		r0 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, defaultValue, scopeOpt...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_GetInt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInt'
+type CentralizedConfigMock_GetInt_Call struct {
+	*mock.Call
+}
+
+// GetInt is a helper method to define mock.On call
+//   - key string
+//   - defaultValue int
+//   - scopeOpt ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) GetInt(key interface{}, defaultValue interface{}, scopeOpt ...interface{}) *CentralizedConfigMock_GetInt_Call {
+	return &CentralizedConfigMock_GetInt_Call{Call: _e.mock.On("GetInt",
+		append([]interface{}{key, defaultValue}, scopeOpt...)...)}
+}
+
+func (_c *CentralizedConfigMock_GetInt_Call) Run(run func(key string, defaultValue int, scopeOpt ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetInt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(int), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetInt_Call) Return(_a0 int, _a1 error) *CentralizedConfigMock_GetInt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetInt_Call) RunAndReturn(run func(string, int, ...centralizedconfiguration.Scope) (int, error)) *CentralizedConfigMock_GetInt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJSON provides a mock function with given fields: key, target, scopeOpt
+func (_m *CentralizedConfigMock) GetJSON(key string, target interface{}, scopeOpt ...centralizedconfiguration.Scope) error {
+	_va := make([]interface{}, len(scopeOpt))
+	for _i := range scopeOpt {
+		_va[_i] = scopeOpt[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJSON")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, ...centralizedconfiguration.Scope) error); ok {
+		r0 = rf(key, target, scopeOpt...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CentralizedConfigMock_GetJSON_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJSON'
+type CentralizedConfigMock_GetJSON_Call struct {
+	*mock.Call
+}
+
+// GetJSON is a helper method to define mock.On call
+//   - key string
+//   - target interface{}
+//   - scopeOpt ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) GetJSON(key interface{}, target interface{}, scopeOpt ...interface{}) *CentralizedConfigMock_GetJSON_Call {
+	return &CentralizedConfigMock_GetJSON_Call{Call: _e.mock.On("GetJSON",
+		append([]interface{}{key, target}, scopeOpt...)...)}
+}
+
+func (_c *CentralizedConfigMock_GetJSON_Call) Run(run func(key string, target interface{}, scopeOpt ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetJSON_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(interface{}), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetJSON_Call) Return(_a0 error) *CentralizedConfigMock_GetJSON_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetJSON_Call) RunAndReturn(run func(string, interface{}, ...centralizedconfiguration.Scope) error) *CentralizedConfigMock_GetJSON_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSecret provides a mock function with given fields: key, scope
+func (_m *CentralizedConfigMock) GetSecret(key string, scope ...centralizedconfiguration.Scope) (centralizedconfiguration.Secret, error) {
+	_va := make([]interface{}, len(scope))
+	for _i := range scope {
+		_va[_i] = scope[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecret")
+	}
+
+	var r0 centralizedconfiguration.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...centralizedconfiguration.Scope) (centralizedconfiguration.Secret, error)); ok {
+		return rf(key, scope...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...centralizedconfiguration.Scope) centralizedconfiguration.Secret); ok {
+		r0 = rf(key, scope...)
+	} else {
+		r0 = ret.Get(0).(centralizedconfiguration.Secret)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, scope...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_GetSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecret'
+type CentralizedConfigMock_GetSecret_Call struct {
+	*mock.Call
+}
+
+// GetSecret is a helper method to define mock.On call
+//   - key string
+//   - scope ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) GetSecret(key interface{}, scope ...interface{}) *CentralizedConfigMock_GetSecret_Call {
+	return &CentralizedConfigMock_GetSecret_Call{Call: _e.mock.On("GetSecret",
+		append([]interface{}{key}, scope...)...)}
+}
+
+func (_c *CentralizedConfigMock_GetSecret_Call) Run(run func(key string, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_GetSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetSecret_Call) Return(_a0 centralizedconfiguration.Secret, _a1 error) *CentralizedConfigMock_GetSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetSecret_Call) RunAndReturn(run func(string, ...centralizedconfiguration.Scope) (centralizedconfiguration.Secret, error)) *CentralizedConfigMock_GetSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVariant provides a mock function with given fields: flag, requestKey
+func (_m *CentralizedConfigMock) GetVariant(flag string, requestKey string) string {
+	ret := _m.Called(flag, requestKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVariant")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(flag, requestKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// CentralizedConfigMock_GetVariant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVariant'
+type CentralizedConfigMock_GetVariant_Call struct {
+	*mock.Call
+}
+
+// GetVariant is a helper method to define mock.On call
+//   - flag string
+//   - requestKey string
+func (_e *CentralizedConfigMock_Expecter) GetVariant(flag interface{}, requestKey interface{}) *CentralizedConfigMock_GetVariant_Call {
+	return &CentralizedConfigMock_GetVariant_Call{Call: _e.mock.On("GetVariant", flag, requestKey)}
+}
+
+func (_c *CentralizedConfigMock_GetVariant_Call) Run(run func(flag string, requestKey string)) *CentralizedConfigMock_GetVariant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetVariant_Call) Return(_a0 string) *CentralizedConfigMock_GetVariant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_GetVariant_Call) RunAndReturn(run func(string, string) string) *CentralizedConfigMock_GetVariant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsEnabled provides a mock function with given fields: flag, requestKey
+func (_m *CentralizedConfigMock) IsEnabled(flag string, requestKey string) bool {
+	ret := _m.Called(flag, requestKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsEnabled")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(flag, requestKey)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// CentralizedConfigMock_IsEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEnabled'
+type CentralizedConfigMock_IsEnabled_Call struct {
+	*mock.Call
+}
+
+// IsEnabled is a helper method to define mock.On call
+//   - flag string
+//   - requestKey string
+func (_e *CentralizedConfigMock_Expecter) IsEnabled(flag interface{}, requestKey interface{}) *CentralizedConfigMock_IsEnabled_Call {
+	return &CentralizedConfigMock_IsEnabled_Call{Call: _e.mock.On("IsEnabled", flag, requestKey)}
+}
+
+func (_c *CentralizedConfigMock_IsEnabled_Call) Run(run func(flag string, requestKey string)) *CentralizedConfigMock_IsEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_IsEnabled_Call) Return(_a0 bool) *CentralizedConfigMock_IsEnabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_IsEnabled_Call) RunAndReturn(run func(string, string) bool) *CentralizedConfigMock_IsEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListConfig provides a mock function with given fields: scope
+func (_m *CentralizedConfigMock) ListConfig(scope ...centralizedconfiguration.Scope) ([]string, error) {
+	_va := make([]interface{}, len(scope))
+	for _i := range scope {
+		_va[_i] = scope[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConfig")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(...centralizedconfiguration.Scope) ([]string, error)); ok {
+		return rf(scope...)
+	}
+	if rf, ok := ret.Get(0).(func(...centralizedconfiguration.Scope) []string); ok {
+		r0 = rf(scope...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(scope...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_ListConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListConfig'
+type CentralizedConfigMock_ListConfig_Call struct {
+	*mock.Call
+}
+
+// ListConfig is a helper method to define mock.On call
+//   - scope ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) ListConfig(scope ...interface{}) *CentralizedConfigMock_ListConfig_Call {
+	return &CentralizedConfigMock_ListConfig_Call{Call: _e.mock.On("ListConfig",
+		append([]interface{}{}, scope...)...)}
+}
+
+func (_c *CentralizedConfigMock_ListConfig_Call) Run(run func(scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_ListConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_ListConfig_Call) Return(_a0 []string, _a1 error) *CentralizedConfigMock_ListConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_ListConfig_Call) RunAndReturn(run func(...centralizedconfiguration.Scope) ([]string, error)) *CentralizedConfigMock_ListConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with no fields
+func (_m *CentralizedConfigMock) Refresh() {
+	_m.Called()
+}
+
+// CentralizedConfigMock_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type CentralizedConfigMock_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+func (_e *CentralizedConfigMock_Expecter) Refresh() *CentralizedConfigMock_Refresh_Call {
+	return &CentralizedConfigMock_Refresh_Call{Call: _e.mock.On("Refresh")}
+}
+
+func (_c *CentralizedConfigMock_Refresh_Call) Run(run func()) *CentralizedConfigMock_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_Refresh_Call) Return() *CentralizedConfigMock_Refresh_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *CentralizedConfigMock_Refresh_Call) RunAndReturn(run func()) *CentralizedConfigMock_Refresh_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetConfig provides a mock function with given fields: key, value, scope
+func (_m *CentralizedConfigMock) SetConfig(key string, value string, scope ...centralizedconfiguration.Scope) error {
+	_va := make([]interface{}, len(scope))
+	for _i := range scope {
+		_va[_i] = scope[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, value)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, ...centralizedconfiguration.Scope) error); ok {
+		r0 = rf(key, value, scope...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CentralizedConfigMock_SetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetConfig'
+type CentralizedConfigMock_SetConfig_Call struct {
+	*mock.Call
+}
+
+// SetConfig is a helper method to define mock.On call
+//   - key string
+//   - value string
+//   - scope ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) SetConfig(key interface{}, value interface{}, scope ...interface{}) *CentralizedConfigMock_SetConfig_Call {
+	return &CentralizedConfigMock_SetConfig_Call{Call: _e.mock.On("SetConfig",
+		append([]interface{}{key, value}, scope...)...)}
+}
+
+func (_c *CentralizedConfigMock_SetConfig_Call) Run(run func(key string, value string, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_SetConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_SetConfig_Call) Return(_a0 error) *CentralizedConfigMock_SetConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_SetConfig_Call) RunAndReturn(run func(string, string, ...centralizedconfiguration.Scope) error) *CentralizedConfigMock_SetConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSecret provides a mock function with given fields: key, value, scope
+func (_m *CentralizedConfigMock) SetSecret(key string, value string, scope ...centralizedconfiguration.Scope) error {
+	_va := make([]interface{}, len(scope))
+	for _i := range scope {
+		_va[_i] = scope[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, value)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, ...centralizedconfiguration.Scope) error); ok {
+		r0 = rf(key, value, scope...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CentralizedConfigMock_SetSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSecret'
+type CentralizedConfigMock_SetSecret_Call struct {
+	*mock.Call
+}
+
+// SetSecret is a helper method to define mock.On call
+//   - key string
+//   - value string
+//   - scope ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) SetSecret(key interface{}, value interface{}, scope ...interface{}) *CentralizedConfigMock_SetSecret_Call {
+	return &CentralizedConfigMock_SetSecret_Call{Call: _e.mock.On("SetSecret",
+		append([]interface{}{key, value}, scope...)...)}
+}
+
+func (_c *CentralizedConfigMock_SetSecret_Call) Run(run func(key string, value string, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_SetSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_SetSecret_Call) Return(_a0 error) *CentralizedConfigMock_SetSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_SetSecret_Call) RunAndReturn(run func(string, string, ...centralizedconfiguration.Scope) error) *CentralizedConfigMock_SetSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateConfig provides a mock function with given fields: key, value, expectedRevision, scope
+func (_m *CentralizedConfigMock) UpdateConfig(key string, value string, expectedRevision uint64, scope ...centralizedconfiguration.Scope) (uint64, error) {
+	_va := make([]interface{}, len(scope))
+	for _i := range scope {
+		_va[_i] = scope[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, value, expectedRevision)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateConfig")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, uint64, ...centralizedconfiguration.Scope) (uint64, error)); ok {
+		return rf(key, value, expectedRevision, scope...)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, uint64, ...centralizedconfiguration.Scope) uint64); ok {
+		r0 = rf(key, value, expectedRevision, scope...)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, uint64, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, value, expectedRevision, scope...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_UpdateConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateConfig'
+type CentralizedConfigMock_UpdateConfig_Call struct {
+	*mock.Call
+}
+
+// UpdateConfig is a helper method to define mock.On call
+//   - key string
+//   - value string
+//   - expectedRevision uint64
+//   - scope ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) UpdateConfig(key interface{}, value interface{}, expectedRevision interface{}, scope ...interface{}) *CentralizedConfigMock_UpdateConfig_Call {
+	return &CentralizedConfigMock_UpdateConfig_Call{Call: _e.mock.On("UpdateConfig",
+		append([]interface{}{key, value, expectedRevision}, scope...)...)}
+}
+
+func (_c *CentralizedConfigMock_UpdateConfig_Call) Run(run func(key string, value string, expectedRevision uint64, scope ...centralizedconfiguration.Scope)) *CentralizedConfigMock_UpdateConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(string), args[2].(uint64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_UpdateConfig_Call) Return(_a0 uint64, _a1 error) *CentralizedConfigMock_UpdateConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_UpdateConfig_Call) RunAndReturn(run func(string, string, uint64, ...centralizedconfiguration.Scope) (uint64, error)) *CentralizedConfigMock_UpdateConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateSchema provides a mock function with given fields: schema
+func (_m *CentralizedConfigMock) ValidateSchema(schema centralizedconfiguration.Schema) error {
+	ret := _m.Called(schema)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateSchema")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(centralizedconfiguration.Schema) error); ok {
+		r0 = rf(schema)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CentralizedConfigMock_ValidateSchema_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateSchema'
+type CentralizedConfigMock_ValidateSchema_Call struct {
+	*mock.Call
+}
+
+// ValidateSchema is a helper method to define mock.On call
+//   - schema centralizedconfiguration.Schema
+func (_e *CentralizedConfigMock_Expecter) ValidateSchema(schema interface{}) *CentralizedConfigMock_ValidateSchema_Call {
+	return &CentralizedConfigMock_ValidateSchema_Call{Call: _e.mock.On("ValidateSchema", schema)}
+}
+
+func (_c *CentralizedConfigMock_ValidateSchema_Call) Run(run func(schema centralizedconfiguration.Schema)) *CentralizedConfigMock_ValidateSchema_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(centralizedconfiguration.Schema))
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_ValidateSchema_Call) Return(_a0 error) *CentralizedConfigMock_ValidateSchema_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_ValidateSchema_Call) RunAndReturn(run func(centralizedconfiguration.Schema) error) *CentralizedConfigMock_ValidateSchema_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchConfig provides a mock function with given fields: key, callback, scopeOpt
+func (_m *CentralizedConfigMock) WatchConfig(key string, callback centralizedconfiguration.WatchCallback, scopeOpt ...centralizedconfiguration.Scope) (*centralizedconfiguration.Watcher, error) {
+	_va := make([]interface{}, len(scopeOpt))
+	for _i := range scopeOpt {
+		_va[_i] = scopeOpt[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, key, callback)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchConfig")
+	}
+
+	var r0 *centralizedconfiguration.Watcher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, centralizedconfiguration.WatchCallback, ...centralizedconfiguration.Scope) (*centralizedconfiguration.Watcher, error)); ok {
+		return rf(key, callback, scopeOpt...)
+	}
+	if rf, ok := ret.Get(0).(func(string, centralizedconfiguration.WatchCallback, ...centralizedconfiguration.Scope) *centralizedconfiguration.Watcher); ok {
+		r0 = rf(key, callback, scopeOpt...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*centralizedconfiguration.Watcher)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, centralizedconfiguration.WatchCallback, ...centralizedconfiguration.Scope) error); ok {
+		r1 = rf(key, callback, scopeOpt...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CentralizedConfigMock_WatchConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchConfig'
+type CentralizedConfigMock_WatchConfig_Call struct {
+	*mock.Call
+}
+
+// WatchConfig is a helper method to define mock.On call
+//   - key string
+//   - callback centralizedconfiguration.WatchCallback
+//   - scopeOpt ...centralizedconfiguration.Scope
+func (_e *CentralizedConfigMock_Expecter) WatchConfig(key interface{}, callback interface{}, scopeOpt ...interface{}) *CentralizedConfigMock_WatchConfig_Call {
+	return &CentralizedConfigMock_WatchConfig_Call{Call: _e.mock.On("WatchConfig",
+		append([]interface{}{key, callback}, scopeOpt...)...)}
+}
+
+func (_c *CentralizedConfigMock_WatchConfig_Call) Run(run func(key string, callback centralizedconfiguration.WatchCallback, scopeOpt ...centralizedconfiguration.Scope)) *CentralizedConfigMock_WatchConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]centralizedconfiguration.Scope, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(centralizedconfiguration.Scope)
+			}
+		}
+		run(args[0].(string), args[1].(centralizedconfiguration.WatchCallback), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CentralizedConfigMock_WatchConfig_Call) Return(_a0 *centralizedconfiguration.Watcher, _a1 error) *CentralizedConfigMock_WatchConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CentralizedConfigMock_WatchConfig_Call) RunAndReturn(run func(string, centralizedconfiguration.WatchCallback, ...centralizedconfiguration.Scope) (*centralizedconfiguration.Watcher, error)) *CentralizedConfigMock_WatchConfig_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,176 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	nats "github.com/nats-io/nats.go"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// KeyWatcherMock is an autogenerated mock type for the KeyWatcher type
+type KeyWatcherMock struct {
+	mock.Mock
+}
+
+type KeyWatcherMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KeyWatcherMock) EXPECT() *KeyWatcherMock_Expecter {
+	return &KeyWatcherMock_Expecter{mock: &_m.Mock}
+}
+
+// Context provides a mock function with no fields
+func (_m *KeyWatcherMock) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// KeyWatcherMock_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type KeyWatcherMock_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *KeyWatcherMock_Expecter) Context() *KeyWatcherMock_Context_Call {
+	return &KeyWatcherMock_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *KeyWatcherMock_Context_Call) Run(run func()) *KeyWatcherMock_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KeyWatcherMock_Context_Call) Return(_a0 context.Context) *KeyWatcherMock_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KeyWatcherMock_Context_Call) RunAndReturn(run func() context.Context) *KeyWatcherMock_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function with no fields
+func (_m *KeyWatcherMock) Stop() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KeyWatcherMock_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type KeyWatcherMock_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+func (_e *KeyWatcherMock_Expecter) Stop() *KeyWatcherMock_Stop_Call {
+	return &KeyWatcherMock_Stop_Call{Call: _e.mock.On("Stop")}
+}
+
+func (_c *KeyWatcherMock_Stop_Call) Run(run func()) *KeyWatcherMock_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KeyWatcherMock_Stop_Call) Return(_a0 error) *KeyWatcherMock_Stop_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KeyWatcherMock_Stop_Call) RunAndReturn(run func() error) *KeyWatcherMock_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Updates provides a mock function with no fields
+func (_m *KeyWatcherMock) Updates() <-chan nats.KeyValueEntry {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Updates")
+	}
+
+	var r0 <-chan nats.KeyValueEntry
+	if rf, ok := ret.Get(0).(func() <-chan nats.KeyValueEntry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan nats.KeyValueEntry)
+		}
+	}
+
+	return r0
+}
+
+// KeyWatcherMock_Updates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Updates'
+type KeyWatcherMock_Updates_Call struct {
+	*mock.Call
+}
+
+// Updates is a helper method to define mock.On call
+func (_e *KeyWatcherMock_Expecter) Updates() *KeyWatcherMock_Updates_Call {
+	return &KeyWatcherMock_Updates_Call{Call: _e.mock.On("Updates")}
+}
+
+func (_c *KeyWatcherMock_Updates_Call) Run(run func()) *KeyWatcherMock_Updates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KeyWatcherMock_Updates_Call) Return(_a0 <-chan nats.KeyValueEntry) *KeyWatcherMock_Updates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KeyWatcherMock_Updates_Call) RunAndReturn(run func() <-chan nats.KeyValueEntry) *KeyWatcherMock_Updates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewKeyWatcherMock creates a new instance of KeyWatcherMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKeyWatcherMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KeyWatcherMock {
+	mock := &KeyWatcherMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,8 +3,14 @@
 package mocks
 
 import (
-	mock "github.com/stretchr/testify/mock"
+	context "context"
+
+	measurement "github.com/konstellation-io/kai-gosdk/sdk/measurement"
 	metric "go.opentelemetry.io/otel/metric"
+
+	mock "github.com/stretchr/testify/mock"
+
+	modelregistry "github.com/konstellation-io/kai-gosdk/sdk/model-registry"
 )
 
 // MeasurementsMock is an autogenerated mock type for the measurements type
@@ -20,6 +26,198 @@ func (_m *MeasurementsMock) EXPECT() *MeasurementsMock_Expecter {
 	return &MeasurementsMock_Expecter{mock: &_m.Mock}
 }
 
+// Counter provides a mock function with given fields: name, opts
+func (_m *MeasurementsMock) Counter(name string, opts ...measurement.InstrumentOption) (*measurement.Counter, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Counter")
+	}
+
+	var r0 *measurement.Counter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) (*measurement.Counter, error)); ok {
+		return rf(name, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) *measurement.Counter); ok {
+		r0 = rf(name, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurement.Counter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...measurement.InstrumentOption) error); ok {
+		r1 = rf(name, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MeasurementsMock_Counter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Counter'
+type MeasurementsMock_Counter_Call struct {
+	*mock.Call
+}
+
+// Counter is a helper method to define mock.On call
+//   - name string
+//   - opts ...measurement.InstrumentOption
+func (_e *MeasurementsMock_Expecter) Counter(name interface{}, opts ...interface{}) *MeasurementsMock_Counter_Call {
+	return &MeasurementsMock_Counter_Call{Call: _e.mock.On("Counter",
+		append([]interface{}{name}, opts...)...)}
+}
+
+func (_c *MeasurementsMock_Counter_Call) Run(run func(name string, opts ...measurement.InstrumentOption)) *MeasurementsMock_Counter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]measurement.InstrumentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(measurement.InstrumentOption)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_Counter_Call) Return(_a0 *measurement.Counter, _a1 error) *MeasurementsMock_Counter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MeasurementsMock_Counter_Call) RunAndReturn(run func(string, ...measurement.InstrumentOption) (*measurement.Counter, error)) *MeasurementsMock_Counter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceFlush provides a mock function with given fields: ctx
+func (_m *MeasurementsMock) ForceFlush(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceFlush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MeasurementsMock_ForceFlush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceFlush'
+type MeasurementsMock_ForceFlush_Call struct {
+	*mock.Call
+}
+
+// ForceFlush is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MeasurementsMock_Expecter) ForceFlush(ctx interface{}) *MeasurementsMock_ForceFlush_Call {
+	return &MeasurementsMock_ForceFlush_Call{Call: _e.mock.On("ForceFlush", ctx)}
+}
+
+func (_c *MeasurementsMock_ForceFlush_Call) Run(run func(ctx context.Context)) *MeasurementsMock_ForceFlush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_ForceFlush_Call) Return(_a0 error) *MeasurementsMock_ForceFlush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MeasurementsMock_ForceFlush_Call) RunAndReturn(run func(context.Context) error) *MeasurementsMock_ForceFlush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Gauge provides a mock function with given fields: name, opts
+func (_m *MeasurementsMock) Gauge(name string, opts ...measurement.InstrumentOption) (*measurement.Gauge, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Gauge")
+	}
+
+	var r0 *measurement.Gauge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) (*measurement.Gauge, error)); ok {
+		return rf(name, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) *measurement.Gauge); ok {
+		r0 = rf(name, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurement.Gauge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...measurement.InstrumentOption) error); ok {
+		r1 = rf(name, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MeasurementsMock_Gauge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Gauge'
+type MeasurementsMock_Gauge_Call struct {
+	*mock.Call
+}
+
+// Gauge is a helper method to define mock.On call
+//   - name string
+//   - opts ...measurement.InstrumentOption
+func (_e *MeasurementsMock_Expecter) Gauge(name interface{}, opts ...interface{}) *MeasurementsMock_Gauge_Call {
+	return &MeasurementsMock_Gauge_Call{Call: _e.mock.On("Gauge",
+		append([]interface{}{name}, opts...)...)}
+}
+
+func (_c *MeasurementsMock_Gauge_Call) Run(run func(name string, opts ...measurement.InstrumentOption)) *MeasurementsMock_Gauge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]measurement.InstrumentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(measurement.InstrumentOption)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_Gauge_Call) Return(_a0 *measurement.Gauge, _a1 error) *MeasurementsMock_Gauge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MeasurementsMock_Gauge_Call) RunAndReturn(run func(string, ...measurement.InstrumentOption) (*measurement.Gauge, error)) *MeasurementsMock_Gauge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMetricsClient provides a mock function with no fields
 func (_m *MeasurementsMock) GetMetricsClient() metric.Meter {
 	ret := _m.Called()
@@ -67,6 +265,303 @@ func (_c *MeasurementsMock_GetMetricsClient_Call) RunAndReturn(run func() metric
 	return _c
 }
 
+// Histogram provides a mock function with given fields: name, opts
+func (_m *MeasurementsMock) Histogram(name string, opts ...measurement.InstrumentOption) (*measurement.Histogram, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Histogram")
+	}
+
+	var r0 *measurement.Histogram
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) (*measurement.Histogram, error)); ok {
+		return rf(name, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) *measurement.Histogram); ok {
+		r0 = rf(name, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurement.Histogram)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...measurement.InstrumentOption) error); ok {
+		r1 = rf(name, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MeasurementsMock_Histogram_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Histogram'
+type MeasurementsMock_Histogram_Call struct {
+	*mock.Call
+}
+
+// Histogram is a helper method to define mock.On call
+//   - name string
+//   - opts ...measurement.InstrumentOption
+func (_e *MeasurementsMock_Expecter) Histogram(name interface{}, opts ...interface{}) *MeasurementsMock_Histogram_Call {
+	return &MeasurementsMock_Histogram_Call{Call: _e.mock.On("Histogram",
+		append([]interface{}{name}, opts...)...)}
+}
+
+func (_c *MeasurementsMock_Histogram_Call) Run(run func(name string, opts ...measurement.InstrumentOption)) *MeasurementsMock_Histogram_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]measurement.InstrumentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(measurement.InstrumentOption)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_Histogram_Call) Return(_a0 *measurement.Histogram, _a1 error) *MeasurementsMock_Histogram_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MeasurementsMock_Histogram_Call) RunAndReturn(run func(string, ...measurement.InstrumentOption) (*measurement.Histogram, error)) *MeasurementsMock_Histogram_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewModelMonitor provides a mock function with given fields: model, opts
+func (_m *MeasurementsMock) NewModelMonitor(model modelregistry.ModelInfo, opts ...measurement.ModelMonitorOption) (*measurement.ModelMonitor, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, model)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewModelMonitor")
+	}
+
+	var r0 *measurement.ModelMonitor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(modelregistry.ModelInfo, ...measurement.ModelMonitorOption) (*measurement.ModelMonitor, error)); ok {
+		return rf(model, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(modelregistry.ModelInfo, ...measurement.ModelMonitorOption) *measurement.ModelMonitor); ok {
+		r0 = rf(model, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurement.ModelMonitor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(modelregistry.ModelInfo, ...measurement.ModelMonitorOption) error); ok {
+		r1 = rf(model, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MeasurementsMock_NewModelMonitor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewModelMonitor'
+type MeasurementsMock_NewModelMonitor_Call struct {
+	*mock.Call
+}
+
+// NewModelMonitor is a helper method to define mock.On call
+//   - model modelregistry.ModelInfo
+//   - opts ...measurement.ModelMonitorOption
+func (_e *MeasurementsMock_Expecter) NewModelMonitor(model interface{}, opts ...interface{}) *MeasurementsMock_NewModelMonitor_Call {
+	return &MeasurementsMock_NewModelMonitor_Call{Call: _e.mock.On("NewModelMonitor",
+		append([]interface{}{model}, opts...)...)}
+}
+
+func (_c *MeasurementsMock_NewModelMonitor_Call) Run(run func(model modelregistry.ModelInfo, opts ...measurement.ModelMonitorOption)) *MeasurementsMock_NewModelMonitor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]measurement.ModelMonitorOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(measurement.ModelMonitorOption)
+			}
+		}
+		run(args[0].(modelregistry.ModelInfo), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_NewModelMonitor_Call) Return(_a0 *measurement.ModelMonitor, _a1 error) *MeasurementsMock_NewModelMonitor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MeasurementsMock_NewModelMonitor_Call) RunAndReturn(run func(modelregistry.ModelInfo, ...measurement.ModelMonitorOption) (*measurement.ModelMonitor, error)) *MeasurementsMock_NewModelMonitor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Shutdown provides a mock function with given fields: ctx
+func (_m *MeasurementsMock) Shutdown(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MeasurementsMock_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MeasurementsMock_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MeasurementsMock_Expecter) Shutdown(ctx interface{}) *MeasurementsMock_Shutdown_Call {
+	return &MeasurementsMock_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *MeasurementsMock_Shutdown_Call) Run(run func(ctx context.Context)) *MeasurementsMock_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_Shutdown_Call) Return(_a0 error) *MeasurementsMock_Shutdown_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MeasurementsMock_Shutdown_Call) RunAndReturn(run func(context.Context) error) *MeasurementsMock_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with no fields
+func (_m *MeasurementsMock) Start() {
+	_m.Called()
+}
+
+// MeasurementsMock_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MeasurementsMock_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+func (_e *MeasurementsMock_Expecter) Start() *MeasurementsMock_Start_Call {
+	return &MeasurementsMock_Start_Call{Call: _e.mock.On("Start")}
+}
+
+func (_c *MeasurementsMock_Start_Call) Run(run func()) *MeasurementsMock_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_Start_Call) Return() *MeasurementsMock_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MeasurementsMock_Start_Call) RunAndReturn(run func()) *MeasurementsMock_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Timer provides a mock function with given fields: name, opts
+func (_m *MeasurementsMock) Timer(name string, opts ...measurement.InstrumentOption) (*measurement.Histogram, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Timer")
+	}
+
+	var r0 *measurement.Histogram
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) (*measurement.Histogram, error)); ok {
+		return rf(name, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...measurement.InstrumentOption) *measurement.Histogram); ok {
+		r0 = rf(name, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurement.Histogram)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...measurement.InstrumentOption) error); ok {
+		r1 = rf(name, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MeasurementsMock_Timer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Timer'
+type MeasurementsMock_Timer_Call struct {
+	*mock.Call
+}
+
+// Timer is a helper method to define mock.On call
+//   - name string
+//   - opts ...measurement.InstrumentOption
+func (_e *MeasurementsMock_Expecter) Timer(name interface{}, opts ...interface{}) *MeasurementsMock_Timer_Call {
+	return &MeasurementsMock_Timer_Call{Call: _e.mock.On("Timer",
+		append([]interface{}{name}, opts...)...)}
+}
+
+func (_c *MeasurementsMock_Timer_Call) Run(run func(name string, opts ...measurement.InstrumentOption)) *MeasurementsMock_Timer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]measurement.InstrumentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(measurement.InstrumentOption)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MeasurementsMock_Timer_Call) Return(_a0 *measurement.Histogram, _a1 error) *MeasurementsMock_Timer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MeasurementsMock_Timer_Call) RunAndReturn(run func(string, ...measurement.InstrumentOption) (*measurement.Histogram, error)) *MeasurementsMock_Timer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMeasurementsMock creates a new instance of MeasurementsMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMeasurementsMock(t interface {
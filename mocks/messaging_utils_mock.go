@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	nats "github.com/nats-io/nats.go"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MessagingUtilsMock is an autogenerated mock type for the messagingUtils type
 type MessagingUtilsMock struct {
@@ -72,6 +75,52 @@ func (_c *MessagingUtilsMock_GetMaxMessageSize_Call) RunAndReturn(run func() (in
 	return _c
 }
 
+// PublishMsg provides a mock function with given fields: msg
+func (_m *MessagingUtilsMock) PublishMsg(msg *nats.Msg) error {
+	ret := _m.Called(msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*nats.Msg) error); ok {
+		r0 = rf(msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MessagingUtilsMock_PublishMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishMsg'
+type MessagingUtilsMock_PublishMsg_Call struct {
+	*mock.Call
+}
+
+// PublishMsg is a helper method to define mock.On call
+//   - msg *nats.Msg
+func (_e *MessagingUtilsMock_Expecter) PublishMsg(msg interface{}) *MessagingUtilsMock_PublishMsg_Call {
+	return &MessagingUtilsMock_PublishMsg_Call{Call: _e.mock.On("PublishMsg", msg)}
+}
+
+func (_c *MessagingUtilsMock_PublishMsg_Call) Run(run func(msg *nats.Msg)) *MessagingUtilsMock_PublishMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*nats.Msg))
+	})
+	return _c
+}
+
+func (_c *MessagingUtilsMock_PublishMsg_Call) Return(_a0 error) *MessagingUtilsMock_PublishMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MessagingUtilsMock_PublishMsg_Call) RunAndReturn(run func(*nats.Msg) error) *MessagingUtilsMock_PublishMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMessagingUtilsMock creates a new instance of MessagingUtilsMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMessagingUtilsMock(t interface {
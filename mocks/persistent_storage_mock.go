@@ -3,8 +3,12 @@
 package mocks
 
 import (
-	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
+	context "context"
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
+
+	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
 )
 
 // PersistentStorageMock is an autogenerated mock type for the persistentStorage type
@@ -154,9 +158,97 @@ func (_c *PersistentStorageMock_Get_Call) RunAndReturn(run func(string, ...strin
 	return _c
 }
 
-// List provides a mock function with no fields
-func (_m *PersistentStorageMock) List() ([]*persistentstorage.ObjectInfo, error) {
-	ret := _m.Called()
+// GetStream provides a mock function with given fields: ctx, key, version, opts
+func (_m *PersistentStorageMock) GetStream(ctx context.Context, key string, version string, opts ...persistentstorage.ObjectOption) (io.ReadCloser, persistentstorage.ObjectInfo, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key, version)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStream")
+	}
+
+	var r0 io.ReadCloser
+	var r1 persistentstorage.ObjectInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...persistentstorage.ObjectOption) (io.ReadCloser, persistentstorage.ObjectInfo, error)); ok {
+		return rf(ctx, key, version, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...persistentstorage.ObjectOption) io.ReadCloser); ok {
+		r0 = rf(ctx, key, version, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...persistentstorage.ObjectOption) persistentstorage.ObjectInfo); ok {
+		r1 = rf(ctx, key, version, opts...)
+	} else {
+		r1 = ret.Get(1).(persistentstorage.ObjectInfo)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, ...persistentstorage.ObjectOption) error); ok {
+		r2 = rf(ctx, key, version, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PersistentStorageMock_GetStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStream'
+type PersistentStorageMock_GetStream_Call struct {
+	*mock.Call
+}
+
+// GetStream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - version string
+//   - opts ...persistentstorage.ObjectOption
+func (_e *PersistentStorageMock_Expecter) GetStream(ctx interface{}, key interface{}, version interface{}, opts ...interface{}) *PersistentStorageMock_GetStream_Call {
+	return &PersistentStorageMock_GetStream_Call{Call: _e.mock.On("GetStream",
+		append([]interface{}{ctx, key, version}, opts...)...)}
+}
+
+func (_c *PersistentStorageMock_GetStream_Call) Run(run func(ctx context.Context, key string, version string, opts ...persistentstorage.ObjectOption)) *PersistentStorageMock_GetStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]persistentstorage.ObjectOption, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(persistentstorage.ObjectOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *PersistentStorageMock_GetStream_Call) Return(_a0 io.ReadCloser, _a1 persistentstorage.ObjectInfo, _a2 error) *PersistentStorageMock_GetStream_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *PersistentStorageMock_GetStream_Call) RunAndReturn(run func(context.Context, string, string, ...persistentstorage.ObjectOption) (io.ReadCloser, persistentstorage.ObjectInfo, error)) *PersistentStorageMock_GetStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: opts
+func (_m *PersistentStorageMock) List(opts ...persistentstorage.ListOption) ([]*persistentstorage.ObjectInfo, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -164,19 +256,19 @@ func (_m *PersistentStorageMock) List() ([]*persistentstorage.ObjectInfo, error)
 
 	var r0 []*persistentstorage.ObjectInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*persistentstorage.ObjectInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(...persistentstorage.ListOption) ([]*persistentstorage.ObjectInfo, error)); ok {
+		return rf(opts...)
 	}
-	if rf, ok := ret.Get(0).(func() []*persistentstorage.ObjectInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(...persistentstorage.ListOption) []*persistentstorage.ObjectInfo); ok {
+		r0 = rf(opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*persistentstorage.ObjectInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(...persistentstorage.ListOption) error); ok {
+		r1 = rf(opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -190,13 +282,21 @@ type PersistentStorageMock_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
-func (_e *PersistentStorageMock_Expecter) List() *PersistentStorageMock_List_Call {
-	return &PersistentStorageMock_List_Call{Call: _e.mock.On("List")}
+//   - opts ...persistentstorage.ListOption
+func (_e *PersistentStorageMock_Expecter) List(opts ...interface{}) *PersistentStorageMock_List_Call {
+	return &PersistentStorageMock_List_Call{Call: _e.mock.On("List",
+		append([]interface{}{}, opts...)...)}
 }
 
-func (_c *PersistentStorageMock_List_Call) Run(run func()) *PersistentStorageMock_List_Call {
+func (_c *PersistentStorageMock_List_Call) Run(run func(opts ...persistentstorage.ListOption)) *PersistentStorageMock_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		variadicArgs := make([]persistentstorage.ListOption, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(persistentstorage.ListOption)
+			}
+		}
+		run(variadicArgs...)
 	})
 	return _c
 }
@@ -206,7 +306,81 @@ func (_c *PersistentStorageMock_List_Call) Return(_a0 []*persistentstorage.Objec
 	return _c
 }
 
-func (_c *PersistentStorageMock_List_Call) RunAndReturn(run func() ([]*persistentstorage.ObjectInfo, error)) *PersistentStorageMock_List_Call {
+func (_c *PersistentStorageMock_List_Call) RunAndReturn(run func(...persistentstorage.ListOption) ([]*persistentstorage.ObjectInfo, error)) *PersistentStorageMock_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPrefix provides a mock function with given fields: ctx, prefix, opts
+func (_m *PersistentStorageMock) ListPrefix(ctx context.Context, prefix string, opts ...persistentstorage.ListPrefixOption) (*persistentstorage.ObjectIterator, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, prefix)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPrefix")
+	}
+
+	var r0 *persistentstorage.ObjectIterator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...persistentstorage.ListPrefixOption) (*persistentstorage.ObjectIterator, error)); ok {
+		return rf(ctx, prefix, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...persistentstorage.ListPrefixOption) *persistentstorage.ObjectIterator); ok {
+		r0 = rf(ctx, prefix, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistentstorage.ObjectIterator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...persistentstorage.ListPrefixOption) error); ok {
+		r1 = rf(ctx, prefix, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersistentStorageMock_ListPrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPrefix'
+type PersistentStorageMock_ListPrefix_Call struct {
+	*mock.Call
+}
+
+// ListPrefix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+//   - opts ...persistentstorage.ListPrefixOption
+func (_e *PersistentStorageMock_Expecter) ListPrefix(ctx interface{}, prefix interface{}, opts ...interface{}) *PersistentStorageMock_ListPrefix_Call {
+	return &PersistentStorageMock_ListPrefix_Call{Call: _e.mock.On("ListPrefix",
+		append([]interface{}{ctx, prefix}, opts...)...)}
+}
+
+func (_c *PersistentStorageMock_ListPrefix_Call) Run(run func(ctx context.Context, prefix string, opts ...persistentstorage.ListPrefixOption)) *PersistentStorageMock_ListPrefix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]persistentstorage.ListPrefixOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(persistentstorage.ListPrefixOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *PersistentStorageMock_ListPrefix_Call) Return(_a0 *persistentstorage.ObjectIterator, _a1 error) *PersistentStorageMock_ListPrefix_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersistentStorageMock_ListPrefix_Call) RunAndReturn(run func(context.Context, string, ...persistentstorage.ListPrefixOption) (*persistentstorage.ObjectIterator, error)) *PersistentStorageMock_ListPrefix_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -269,6 +443,54 @@ func (_c *PersistentStorageMock_ListVersions_Call) RunAndReturn(run func(string)
 	return _c
 }
 
+// RemoveTTL provides a mock function with given fields: ctx, key, version
+func (_m *PersistentStorageMock) RemoveTTL(ctx context.Context, key string, version string) error {
+	ret := _m.Called(ctx, key, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTTL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersistentStorageMock_RemoveTTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTTL'
+type PersistentStorageMock_RemoveTTL_Call struct {
+	*mock.Call
+}
+
+// RemoveTTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - version string
+func (_e *PersistentStorageMock_Expecter) RemoveTTL(ctx interface{}, key interface{}, version interface{}) *PersistentStorageMock_RemoveTTL_Call {
+	return &PersistentStorageMock_RemoveTTL_Call{Call: _e.mock.On("RemoveTTL", ctx, key, version)}
+}
+
+func (_c *PersistentStorageMock_RemoveTTL_Call) Run(run func(ctx context.Context, key string, version string)) *PersistentStorageMock_RemoveTTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PersistentStorageMock_RemoveTTL_Call) Return(_a0 error) *PersistentStorageMock_RemoveTTL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersistentStorageMock_RemoveTTL_Call) RunAndReturn(run func(context.Context, string, string) error) *PersistentStorageMock_RemoveTTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: key, value, ttlDays
 func (_m *PersistentStorageMock) Save(key string, value []byte, ttlDays ...int) (*persistentstorage.ObjectInfo, error) {
 	_va := make([]interface{}, len(ttlDays))
@@ -343,6 +565,143 @@ func (_c *PersistentStorageMock_Save_Call) RunAndReturn(run func(string, []byte,
 	return _c
 }
 
+// SaveStream provides a mock function with given fields: ctx, key, reader, size, opts
+func (_m *PersistentStorageMock) SaveStream(ctx context.Context, key string, reader io.Reader, size int64, opts ...persistentstorage.ObjectOption) (*persistentstorage.ObjectInfo, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key, reader, size)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveStream")
+	}
+
+	var r0 *persistentstorage.ObjectInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, ...persistentstorage.ObjectOption) (*persistentstorage.ObjectInfo, error)); ok {
+		return rf(ctx, key, reader, size, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, ...persistentstorage.ObjectOption) *persistentstorage.ObjectInfo); ok {
+		r0 = rf(ctx, key, reader, size, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistentstorage.ObjectInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader, int64, ...persistentstorage.ObjectOption) error); ok {
+		r1 = rf(ctx, key, reader, size, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersistentStorageMock_SaveStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveStream'
+type PersistentStorageMock_SaveStream_Call struct {
+	*mock.Call
+}
+
+// SaveStream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - reader io.Reader
+//   - size int64
+//   - opts ...persistentstorage.ObjectOption
+func (_e *PersistentStorageMock_Expecter) SaveStream(ctx interface{}, key interface{}, reader interface{}, size interface{}, opts ...interface{}) *PersistentStorageMock_SaveStream_Call {
+	return &PersistentStorageMock_SaveStream_Call{Call: _e.mock.On("SaveStream",
+		append([]interface{}{ctx, key, reader, size}, opts...)...)}
+}
+
+func (_c *PersistentStorageMock_SaveStream_Call) Run(run func(ctx context.Context, key string, reader io.Reader, size int64, opts ...persistentstorage.ObjectOption)) *PersistentStorageMock_SaveStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]persistentstorage.ObjectOption, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(persistentstorage.ObjectOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader), args[3].(int64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *PersistentStorageMock_SaveStream_Call) Return(_a0 *persistentstorage.ObjectInfo, _a1 error) *PersistentStorageMock_SaveStream_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersistentStorageMock_SaveStream_Call) RunAndReturn(run func(context.Context, string, io.Reader, int64, ...persistentstorage.ObjectOption) (*persistentstorage.ObjectInfo, error)) *PersistentStorageMock_SaveStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTTL provides a mock function with given fields: ctx, key, version, ttlDays
+func (_m *PersistentStorageMock) SetTTL(ctx context.Context, key string, version string, ttlDays int) (*persistentstorage.ObjectInfo, error) {
+	ret := _m.Called(ctx, key, version, ttlDays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTTL")
+	}
+
+	var r0 *persistentstorage.ObjectInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*persistentstorage.ObjectInfo, error)); ok {
+		return rf(ctx, key, version, ttlDays)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *persistentstorage.ObjectInfo); ok {
+		r0 = rf(ctx, key, version, ttlDays)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistentstorage.ObjectInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, key, version, ttlDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersistentStorageMock_SetTTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTTL'
+type PersistentStorageMock_SetTTL_Call struct {
+	*mock.Call
+}
+
+// SetTTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - version string
+//   - ttlDays int
+func (_e *PersistentStorageMock_Expecter) SetTTL(ctx interface{}, key interface{}, version interface{}, ttlDays interface{}) *PersistentStorageMock_SetTTL_Call {
+	return &PersistentStorageMock_SetTTL_Call{Call: _e.mock.On("SetTTL", ctx, key, version, ttlDays)}
+}
+
+func (_c *PersistentStorageMock_SetTTL_Call) Run(run func(ctx context.Context, key string, version string, ttlDays int)) *PersistentStorageMock_SetTTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *PersistentStorageMock_SetTTL_Call) Return(_a0 *persistentstorage.ObjectInfo, _a1 error) *PersistentStorageMock_SetTTL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersistentStorageMock_SetTTL_Call) RunAndReturn(run func(context.Context, string, string, int) (*persistentstorage.ObjectInfo, error)) *PersistentStorageMock_SetTTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewPersistentStorageMock creates a new instance of PersistentStorageMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPersistentStorageMock(t interface {
@@ -1,16 +1,21 @@
 package common
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/konstellation-io/kai-gosdk/internal/common"
 	kaisdk "github.com/konstellation-io/kai-gosdk/sdk"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
 )
 
 const (
 	_processConfigLoggerName = "[CONFIG INITIALIZER]"
+	_measurementsLoggerName  = "[MEASUREMENTS]"
 )
 
 type Task func(sdk kaisdk.KaiSDK)
@@ -36,3 +41,45 @@ func InitializeProcessConfiguration(sdk kaisdk.KaiSDK) {
 
 	sdk.Logger.WithName(_processConfigLoggerName).V(1).Info("Process configuration initialized")
 }
+
+// ValidateProcessConfiguration checks the configuration against the schema declared in
+// centralized_configuration.process.schema, panicking with the violations found so the process does not start.
+func ValidateProcessConfiguration(sdk kaisdk.KaiSDK) {
+	var schema centralizedConfiguration.Schema
+
+	err := viper.UnmarshalKey(common.ConfigCcProcessSchemaKey, &schema)
+	if err != nil {
+		panic(fmt.Sprintf("invalid process configuration schema: %s", err))
+	}
+
+	if len(schema) == 0 {
+		return
+	}
+
+	sdk.Logger.WithName(_processConfigLoggerName).V(1).Info("Validating process configuration")
+
+	err = sdk.CentralizedConfig.ValidateSchema(schema)
+	if err != nil {
+		sdk.Logger.WithName(_processConfigLoggerName).Error(err, "Invalid process configuration")
+		panic(fmt.Sprintf("invalid process configuration: %s", err))
+	}
+
+	sdk.Logger.WithName(_processConfigLoggerName).V(1).Info("Process configuration validated")
+}
+
+func StartMeasurements(sdk kaisdk.KaiSDK) {
+	sdk.Measurements.Start()
+}
+
+// ShutdownMeasurements exports the metrics recorded since the last export interval before the process stops.
+func ShutdownMeasurements(sdk kaisdk.KaiSDK) {
+	timeout := time.Duration(viper.GetInt(common.ConfigMeasurementsShutdownTimeoutKey)) * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := sdk.Measurements.Shutdown(ctx)
+	if err != nil {
+		sdk.Logger.WithName(_measurementsLoggerName).Error(err, "Error shutting down measurements")
+	}
+}
@@ -3,16 +3,21 @@
 package common_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	internalCommon "github.com/konstellation-io/kai-gosdk/internal/common"
 	"github.com/konstellation-io/kai-gosdk/mocks"
 	"github.com/konstellation-io/kai-gosdk/runner/common"
 	"github.com/konstellation-io/kai-gosdk/sdk"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
 )
 
 type RunnerCommonTestSuite struct {
@@ -38,6 +43,7 @@ func (s *RunnerCommonTestSuite) SetupTest() {
 			Persistent: mocks.NewPersistentStorageMock(s.T()),
 		},
 		CentralizedConfig: mocks.NewCentralizedConfigMock(s.T()),
+		Measurements:      mocks.NewMeasurementsMock(s.T()),
 	}
 }
 
@@ -62,6 +68,93 @@ func (s *RunnerCommonTestSuite) TestInitializeProcessConfiguration_WhenCalledWit
 	s.sdk.CentralizedConfig.(*mocks.CentralizedConfigMock).AssertNotCalled(s.T(), "SetConfig")
 }
 
+func (s *RunnerCommonTestSuite) TestValidateProcessConfiguration_WhenNoSchema_ExpectNotValidated() {
+	// When
+	common.ValidateProcessConfiguration(s.sdk)
+
+	// Then
+	s.sdk.CentralizedConfig.(*mocks.CentralizedConfigMock).AssertNotCalled(s.T(), "ValidateSchema")
+}
+
+func (s *RunnerCommonTestSuite) TestValidateProcessConfiguration_WhenConfigMatchesSchema_ExpectOk() {
+	// Given
+	viper.Set(internalCommon.ConfigCcProcessSchemaKey, map[string]any{
+		"threshold": map[string]any{"type": "float", "required": true, "min": 0, "max": 1},
+		"model":     map[string]any{"enum": []string{"resnet", "vgg"}},
+	})
+	s.sdk.CentralizedConfig.(*mocks.CentralizedConfigMock).
+		On("ValidateSchema", centralizedConfiguration.Schema{
+			"threshold": {Type: centralizedConfiguration.FloatField, Required: true, Min: ptr(0.0), Max: ptr(1.0)},
+			"model":     {Enum: []string{"resnet", "vgg"}},
+		}).Return(nil)
+
+	// When
+	common.ValidateProcessConfiguration(s.sdk)
+
+	// Then
+	s.sdk.CentralizedConfig.(*mocks.CentralizedConfigMock).AssertNumberOfCalls(s.T(), "ValidateSchema", 1)
+}
+
+func (s *RunnerCommonTestSuite) TestValidateProcessConfiguration_WhenConfigDoesNotMatchSchema_ExpectPanic() {
+	// Given
+	viper.Set(internalCommon.ConfigCcProcessSchemaKey, map[string]any{
+		"threshold": map[string]any{"type": "float", "required": true},
+	})
+	s.sdk.CentralizedConfig.(*mocks.CentralizedConfigMock).
+		On("ValidateSchema", mock.Anything).
+		Return(&centralizedConfiguration.SchemaValidationError{
+			Violations: []string{"threshold: required but not set in any scope"},
+		})
+
+	// When - Then
+	s.PanicsWithValue("invalid process configuration: configuration does not match the schema:\n"+
+		"  - threshold: required but not set in any scope", func() {
+		common.ValidateProcessConfiguration(s.sdk)
+	})
+}
+
+func (s *RunnerCommonTestSuite) TestStartMeasurements_ExpectMeasurementsStarted() {
+	// Given
+	s.sdk.Measurements.(*mocks.MeasurementsMock).On("Start").Return()
+
+	// When
+	common.StartMeasurements(s.sdk)
+
+	// Then
+	s.sdk.Measurements.(*mocks.MeasurementsMock).AssertCalled(s.T(), "Start")
+}
+
+func (s *RunnerCommonTestSuite) TestShutdownMeasurements_ExpectMeasurementsFlushedWithTimeout() {
+	// Given
+	viper.Set(internalCommon.ConfigMeasurementsShutdownTimeoutKey, 5)
+	s.sdk.Measurements.(*mocks.MeasurementsMock).
+		On("Shutdown", mock.MatchedBy(func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		})).Return(nil)
+
+	// When
+	common.ShutdownMeasurements(s.sdk)
+
+	// Then
+	s.sdk.Measurements.(*mocks.MeasurementsMock).AssertNumberOfCalls(s.T(), "Shutdown", 1)
+}
+
+func (s *RunnerCommonTestSuite) TestShutdownMeasurements_WhenShutdownFails_ExpectNoPanic() {
+	// Given
+	s.sdk.Measurements.(*mocks.MeasurementsMock).On("Shutdown", mock.Anything).Return(errors.New("export failed"))
+
+	// When
+	common.ShutdownMeasurements(s.sdk)
+
+	// Then
+	s.sdk.Measurements.(*mocks.MeasurementsMock).AssertNumberOfCalls(s.T(), "Shutdown", 1)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
 func TestRunnerCommonTestSuite(t *testing.T) {
 	suite.Run(t, new(RunnerCommonTestSuite))
 }
@@ -0,0 +1,310 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+)
+
+var (
+	ErrInvalidDeliverPolicy = errors.New("invalid deliver policy")
+	ErrMissingStartTime     = errors.New("the start time is mandatory for the by_start_time deliver policy")
+	ErrMissingStartSequence = errors.New("the start sequence is mandatory for the by_start_sequence deliver policy")
+	ErrMissingResumeFrom    = errors.New("the consumer to resume from is mandatory for the resume deliver policy")
+	ErrIdleHeartbeat        = errors.New("idle heartbeats are not supported, runners subscribe with queue subscriptions")
+)
+
+const _consumerLoggerName = "[CONSUMER CONFIG]"
+
+type DeliverPolicy string
+
+const (
+	DeliverAll             DeliverPolicy = "all"
+	DeliverLast            DeliverPolicy = "last"
+	DeliverNew             DeliverPolicy = "new"
+	DeliverLastPerSubject  DeliverPolicy = "last_per_subject"
+	DeliverByStartTime     DeliverPolicy = "by_start_time"
+	DeliverByStartSequence DeliverPolicy = "by_start_sequence"
+	// DeliverResume starts after the last message acknowledged by another consumer of the stream,
+	// typically the one of a previous deployment.
+	DeliverResume DeliverPolicy = "resume"
+)
+
+// ConsumerConfig is the JetStream consumer configuration of an input subject,
+// defined in the runner.subscriber.consumers list of the runner configuration.
+type ConsumerConfig struct {
+	Subject       string        `mapstructure:"subject"`
+	DurableName   string        `mapstructure:"durable_name"`
+	DeliverPolicy DeliverPolicy `mapstructure:"deliver_policy"`
+	StartTime     string        `mapstructure:"start_time"`
+	StartSequence uint64        `mapstructure:"start_sequence"`
+	ResumeFrom    string        `mapstructure:"resume_from"`
+	AckWait       time.Duration `mapstructure:"ack_wait"`
+	MaxDeliver    int           `mapstructure:"max_deliver"`
+	MaxAckPending int           `mapstructure:"max_ack_pending"`
+	RateLimit     uint64        `mapstructure:"rate_limit"`
+	// IdleHeartbeat is rejected, as NATS does not support heartbeats along with queue subscriptions.
+	IdleHeartbeat time.Duration `mapstructure:"idle_heartbeat"`
+}
+
+// GetConsumerConfig returns the consumer configuration of the given input subject,
+// filling the undefined values with the runner defaults.
+func GetConsumerConfig(subject string) (ConsumerConfig, error) {
+	var consumers []ConsumerConfig
+
+	err := viper.UnmarshalKey(common.ConfigRunnerSubscriberConsumersKey, &consumers)
+	if err != nil {
+		return ConsumerConfig{}, fmt.Errorf("error reading consumers configuration: %w", err)
+	}
+
+	config := ConsumerConfig{Subject: subject}
+
+	for _, consumer := range consumers {
+		if consumer.Subject == subject {
+			config = consumer
+			break
+		}
+	}
+
+	if config.DeliverPolicy == "" {
+		config.DeliverPolicy = DeliverNew
+	}
+
+	if config.AckWait == 0 {
+		config.AckWait = viper.GetDuration(common.ConfigRunnerSubscriberAckWaitTimeKey)
+	}
+
+	return config, config.validate()
+}
+
+func (c ConsumerConfig) validate() error {
+	if c.IdleHeartbeat > 0 {
+		return fmt.Errorf("%w, remove idle_heartbeat for subject %s", ErrIdleHeartbeat, c.Subject)
+	}
+
+	switch c.DeliverPolicy {
+	case DeliverAll, DeliverLast, DeliverNew, DeliverLastPerSubject:
+		return nil
+	case DeliverByStartTime:
+		if c.StartTime == "" {
+			return ErrMissingStartTime
+		}
+
+		_, err := time.Parse(time.RFC3339, c.StartTime)
+		if err != nil {
+			return fmt.Errorf("invalid start time for subject %s: %w", c.Subject, err)
+		}
+
+		return nil
+	case DeliverByStartSequence:
+		if c.StartSequence == 0 {
+			return ErrMissingStartSequence
+		}
+
+		return nil
+	case DeliverResume:
+		if c.ResumeFrom == "" {
+			return ErrMissingResumeFrom
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w %q for subject %s", ErrInvalidDeliverPolicy, c.DeliverPolicy, c.Subject)
+	}
+}
+
+// GetDurableName returns the configured durable name or the given default one.
+func (c ConsumerConfig) GetDurableName(defaultName string) string {
+	if c.DurableName != "" {
+		return c.DurableName
+	}
+
+	return defaultName
+}
+
+// GetSubscribeOptions builds the JetStream subscription options for the given durable consumer.
+// The deliver policy only applies when the consumer is created, an existing consumer keeps its position and is
+// updated with the configured ack wait, max deliver, max ack pending and rate limit.
+func (c ConsumerConfig) GetSubscribeOptions(logger logr.Logger, js nats.JetStreamContext,
+	durableName string, pull bool,
+) ([]nats.SubOpt, error) {
+	opts := []nats.SubOpt{nats.AckWait(c.AckWait)}
+
+	if !pull {
+		opts = append(opts, nats.Durable(durableName), nats.ManualAck())
+
+		if c.RateLimit > 0 {
+			opts = append(opts, nats.RateLimit(c.RateLimit))
+		}
+	}
+
+	if c.MaxDeliver > 0 {
+		opts = append(opts, nats.MaxDeliver(c.MaxDeliver))
+	}
+
+	if c.MaxAckPending > 0 {
+		opts = append(opts, nats.MaxAckPending(c.MaxAckPending))
+	}
+
+	stream := viper.GetString(common.ConfigNatsStreamKey)
+
+	info, err := js.ConsumerInfo(stream, durableName)
+	if err == nil {
+		err = c.updateConsumer(logger, js, stream, info, pull)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.WithName(_consumerLoggerName).V(1).
+			Info(fmt.Sprintf("Consumer %s already exists, resuming from its last position", durableName))
+
+		return opts, nil
+	}
+
+	if !errors.Is(err, nats.ErrConsumerNotFound) {
+		return nil, fmt.Errorf("error getting consumer %s info: %w", durableName, err)
+	}
+
+	deliverConfig, err := c.getDeliverConfig(logger, js, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(opts, getDeliverOption(deliverConfig)), nil
+}
+
+// updateConsumer applies the configured limits to an existing consumer, as subscribing with limits other than the
+// ones of the consumer fails.
+func (c ConsumerConfig) updateConsumer(logger logr.Logger, js nats.JetStreamContext, stream string,
+	info *nats.ConsumerInfo, pull bool,
+) error {
+	config := info.Config
+	config.AckWait = c.AckWait
+
+	if c.MaxDeliver > 0 {
+		config.MaxDeliver = c.MaxDeliver
+	}
+
+	if c.MaxAckPending > 0 {
+		config.MaxAckPending = c.MaxAckPending
+	}
+
+	if !pull && c.RateLimit > 0 {
+		config.RateLimit = c.RateLimit
+	}
+
+	if config.AckWait == info.Config.AckWait && config.MaxDeliver == info.Config.MaxDeliver &&
+		config.MaxAckPending == info.Config.MaxAckPending && config.RateLimit == info.Config.RateLimit {
+		return nil
+	}
+
+	_, err := js.UpdateConsumer(stream, &config)
+	if err != nil {
+		return fmt.Errorf("error updating the configuration of consumer %s: %w", info.Name, err)
+	}
+
+	logger.WithName(_consumerLoggerName).
+		Info(fmt.Sprintf("Consumer %s updated with ack wait %s, max deliver %d, max ack pending %d and rate limit %d",
+			info.Name, config.AckWait, config.MaxDeliver, config.MaxAckPending, config.RateLimit))
+
+	return nil
+}
+
+// getDeliverConfig returns the deliver policy, and its start sequence or time, of a new consumer.
+func (c ConsumerConfig) getDeliverConfig(logger logr.Logger, js nats.JetStreamContext,
+	stream string,
+) (nats.ConsumerConfig, error) {
+	switch c.DeliverPolicy {
+	case DeliverAll:
+		return nats.ConsumerConfig{DeliverPolicy: nats.DeliverAllPolicy}, nil
+	case DeliverLast:
+		return nats.ConsumerConfig{DeliverPolicy: nats.DeliverLastPolicy}, nil
+	case DeliverLastPerSubject:
+		return nats.ConsumerConfig{DeliverPolicy: nats.DeliverLastPerSubjectPolicy}, nil
+	case DeliverByStartTime:
+		startTime, err := time.Parse(time.RFC3339, c.StartTime)
+		if err != nil {
+			return nats.ConsumerConfig{}, fmt.Errorf("invalid start time for subject %s: %w", c.Subject, err)
+		}
+
+		return nats.ConsumerConfig{DeliverPolicy: nats.DeliverByStartTimePolicy, OptStartTime: &startTime}, nil
+	case DeliverByStartSequence:
+		return nats.ConsumerConfig{DeliverPolicy: nats.DeliverByStartSequencePolicy, OptStartSeq: c.StartSequence}, nil
+	case DeliverResume:
+		return c.getResumeConfig(logger, js, stream)
+	case DeliverNew:
+		return nats.ConsumerConfig{DeliverPolicy: nats.DeliverNewPolicy}, nil
+	default:
+		return nats.ConsumerConfig{}, fmt.Errorf("%w %q for subject %s", ErrInvalidDeliverPolicy, c.DeliverPolicy, c.Subject)
+	}
+}
+
+func (c ConsumerConfig) getResumeConfig(logger logr.Logger, js nats.JetStreamContext,
+	stream string,
+) (nats.ConsumerConfig, error) {
+	info, err := js.ConsumerInfo(stream, c.ResumeFrom)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		logger.WithName(_consumerLoggerName).
+			Info(fmt.Sprintf("Consumer %s to resume from not found, delivering only new messages", c.ResumeFrom))
+
+		return nats.ConsumerConfig{DeliverPolicy: nats.DeliverNewPolicy}, nil
+	} else if err != nil {
+		return nats.ConsumerConfig{}, fmt.Errorf("error getting consumer %s info: %w", c.ResumeFrom, err)
+	}
+
+	// A consumer that acknowledged nothing has no position, the new one starts where it did, if known.
+	if info.AckFloor.Stream == 0 {
+		switch info.Config.DeliverPolicy {
+		case nats.DeliverAllPolicy, nats.DeliverByStartSequencePolicy, nats.DeliverByStartTimePolicy:
+			logger.WithName(_consumerLoggerName).
+				Info(fmt.Sprintf("Consumer %s acknowledged no messages yet, starting where it started", c.ResumeFrom))
+
+			return nats.ConsumerConfig{
+				DeliverPolicy: info.Config.DeliverPolicy,
+				OptStartSeq:   info.Config.OptStartSeq,
+				OptStartTime:  info.Config.OptStartTime,
+			}, nil
+		default:
+			logger.WithName(_consumerLoggerName).
+				Info(fmt.Sprintf("Consumer %s acknowledged no messages yet, delivering only new messages", c.ResumeFrom))
+
+			return nats.ConsumerConfig{DeliverPolicy: nats.DeliverNewPolicy}, nil
+		}
+	}
+
+	logger.WithName(_consumerLoggerName).
+		Info(fmt.Sprintf("Resuming from stream sequence %d acknowledged by consumer %s",
+			info.AckFloor.Stream+1, c.ResumeFrom))
+
+	return nats.ConsumerConfig{
+		DeliverPolicy: nats.DeliverByStartSequencePolicy,
+		OptStartSeq:   info.AckFloor.Stream + 1,
+	}, nil
+}
+
+func getDeliverOption(config nats.ConsumerConfig) nats.SubOpt {
+	switch config.DeliverPolicy {
+	case nats.DeliverAllPolicy:
+		return nats.DeliverAll()
+	case nats.DeliverLastPolicy:
+		return nats.DeliverLast()
+	case nats.DeliverLastPerSubjectPolicy:
+		return nats.DeliverLastPerSubject()
+	case nats.DeliverByStartTimePolicy:
+		if config.OptStartTime != nil {
+			return nats.StartTime(*config.OptStartTime)
+		}
+
+		return nats.DeliverNew()
+	case nats.DeliverByStartSequencePolicy:
+		return nats.StartSequence(config.OptStartSeq)
+	default:
+		return nats.DeliverNew()
+	}
+}
@@ -0,0 +1,349 @@
+//go:build unit
+
+package common_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	internalCommon "github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	"github.com/konstellation-io/kai-gosdk/runner/common"
+)
+
+const (
+	_testStream  = "test-stream"
+	_testSubject = "test-subject"
+	_testDurable = "test-durable"
+)
+
+type ConsumerConfigTestSuite struct {
+	suite.Suite
+	logger logr.Logger
+	js     *mocks.JetStreamContextMock
+}
+
+func (s *ConsumerConfigTestSuite) SetupSuite() {
+	s.logger = testr.NewWithOptions(s.T(), testr.Options{Verbosity: 1})
+}
+
+func (s *ConsumerConfigTestSuite) SetupTest() {
+	viper.Reset()
+	viper.Set(internalCommon.ConfigNatsStreamKey, _testStream)
+	viper.Set(internalCommon.ConfigRunnerSubscriberAckWaitTimeKey, 22*time.Hour)
+
+	s.js = mocks.NewJetStreamContextMock(s.T())
+}
+
+func (s *ConsumerConfigTestSuite) TestGetConsumerConfig_WhenNotConfigured_ExpectDefaults() {
+	// When
+	config, err := common.GetConsumerConfig(_testSubject)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(_testSubject, config.Subject)
+	s.Equal(common.DeliverNew, config.DeliverPolicy)
+	s.Equal(22*time.Hour, config.AckWait)
+	s.Equal("default-durable", config.GetDurableName("default-durable"))
+}
+
+func (s *ConsumerConfigTestSuite) TestGetConsumerConfig_WhenConfigured_ExpectSubjectConfig() {
+	// Given
+	viper.Set(internalCommon.ConfigRunnerSubscriberConsumersKey, []map[string]interface{}{
+		{"subject": "other-subject", "deliver_policy": "all"},
+		{
+			"subject":         _testSubject,
+			"durable_name":    _testDurable,
+			"deliver_policy":  "by_start_sequence",
+			"start_sequence":  10,
+			"ack_wait":        "30s",
+			"max_deliver":     5,
+			"max_ack_pending": 100,
+		},
+	})
+
+	// When
+	config, err := common.GetConsumerConfig(_testSubject)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(common.DeliverByStartSequence, config.DeliverPolicy)
+	s.Equal(uint64(10), config.StartSequence)
+	s.Equal(30*time.Second, config.AckWait)
+	s.Equal(5, config.MaxDeliver)
+	s.Equal(100, config.MaxAckPending)
+	s.Equal(_testDurable, config.GetDurableName("default-durable"))
+}
+
+func (s *ConsumerConfigTestSuite) TestGetConsumerConfig_WhenInvalidConfig_ExpectError() {
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		err    error
+	}{
+		{"invalid policy", map[string]interface{}{"deliver_policy": "oldest"}, common.ErrInvalidDeliverPolicy},
+		{"missing start time", map[string]interface{}{"deliver_policy": "by_start_time"}, common.ErrMissingStartTime},
+		{"missing sequence", map[string]interface{}{"deliver_policy": "by_start_sequence"}, common.ErrMissingStartSequence},
+		{"missing resume from", map[string]interface{}{"deliver_policy": "resume"}, common.ErrMissingResumeFrom},
+		{"idle heartbeat", map[string]interface{}{"idle_heartbeat": "5s"}, common.ErrIdleHeartbeat},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.config["subject"] = _testSubject
+			viper.Set(internalCommon.ConfigRunnerSubscriberConsumersKey, []map[string]interface{}{tt.config})
+
+			// When
+			_, err := common.GetConsumerConfig(_testSubject)
+
+			// Then
+			s.ErrorIs(err, tt.err)
+		})
+	}
+}
+
+func (s *ConsumerConfigTestSuite) TestGetConsumerConfig_WhenInvalidStartTime_ExpectError() {
+	// Given
+	viper.Set(internalCommon.ConfigRunnerSubscriberConsumersKey, []map[string]interface{}{
+		{"subject": _testSubject, "deliver_policy": "by_start_time", "start_time": "yesterday"},
+	})
+
+	// When
+	_, err := common.GetConsumerConfig(_testSubject)
+
+	// Then
+	s.Error(err)
+}
+
+func (s *ConsumerConfigTestSuite) TestGetSubscribeOptions_WhenConsumerExists_ExpectNoDeliverOption() {
+	// Given
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+	s.js.On("ConsumerInfo", _testStream, _testDurable).
+		Return(&nats.ConsumerInfo{Name: _testDurable, Config: nats.ConsumerConfig{AckWait: 22 * time.Hour}}, nil)
+
+	// When
+	opts, err := config.GetSubscribeOptions(s.logger, s.js, _testDurable, false)
+
+	// Then
+	s.Require().NoError(err)
+	s.Len(opts, 3)
+	s.js.AssertNotCalled(s.T(), "UpdateConsumer")
+}
+
+func (s *ConsumerConfigTestSuite) TestGetSubscribeOptions_WhenExistingConsumerConfigChanged_ExpectConsumerUpdated() {
+	// Given
+	viper.Set(internalCommon.ConfigRunnerSubscriberConsumersKey, []map[string]interface{}{
+		{"subject": _testSubject, "ack_wait": "30s", "max_deliver": 5, "max_ack_pending": 100, "rate_limit": 1024},
+	})
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+
+	existing := nats.ConsumerConfig{
+		Durable:       _testDurable,
+		DeliverPolicy: nats.DeliverNewPolicy,
+		AckWait:       22 * time.Hour,
+		MaxDeliver:    -1,
+		MaxAckPending: 1000,
+	}
+	s.js.On("ConsumerInfo", _testStream, _testDurable).
+		Return(&nats.ConsumerInfo{Name: _testDurable, Config: existing}, nil)
+	s.js.On("UpdateConsumer", _testStream, mock.AnythingOfType("*nats.ConsumerConfig")).
+		Return(&nats.ConsumerInfo{}, nil)
+
+	// When
+	_, err = config.GetSubscribeOptions(s.logger, s.js, _testDurable, false)
+
+	// Then
+	s.Require().NoError(err)
+	s.js.AssertCalled(s.T(), "UpdateConsumer", _testStream, &nats.ConsumerConfig{
+		Durable:       _testDurable,
+		DeliverPolicy: nats.DeliverNewPolicy,
+		AckWait:       30 * time.Second,
+		MaxDeliver:    5,
+		MaxAckPending: 100,
+		RateLimit:     1024,
+	})
+}
+
+func (s *ConsumerConfigTestSuite) TestGetSubscribeOptions_WhenConsumerUpdateFails_ExpectError() {
+	// Given
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+	s.js.On("ConsumerInfo", _testStream, _testDurable).
+		Return(&nats.ConsumerInfo{Name: _testDurable, Config: nats.ConsumerConfig{AckWait: time.Minute}}, nil)
+	s.js.On("UpdateConsumer", _testStream, mock.AnythingOfType("*nats.ConsumerConfig")).
+		Return(nil, errors.New("consumer update failed"))
+
+	// When
+	_, err = config.GetSubscribeOptions(s.logger, s.js, _testDurable, false)
+
+	// Then
+	s.ErrorContains(err, "error updating the configuration of consumer test-durable")
+}
+
+func (s *ConsumerConfigTestSuite) TestGetSubscribeOptions_WhenConsumerNotFound_ExpectDeliverOption() {
+	// Given
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+	s.js.On("ConsumerInfo", _testStream, _testDurable).Return(nil, nats.ErrConsumerNotFound)
+
+	// When
+	opts, err := config.GetSubscribeOptions(s.logger, s.js, _testDurable, false)
+
+	// Then
+	s.Require().NoError(err)
+	s.Len(opts, 4)
+}
+
+func (s *ConsumerConfigTestSuite) TestGetSubscribeOptions_WhenPullConsumer_ExpectNoPushOptions() {
+	// Given
+	viper.Set(internalCommon.ConfigRunnerSubscriberConsumersKey, []map[string]interface{}{
+		{"subject": _testSubject, "rate_limit": 1024, "max_ack_pending": 10},
+	})
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+	s.js.On("ConsumerInfo", _testStream, _testDurable).Return(&nats.ConsumerInfo{
+		Name:   _testDurable,
+		Config: nats.ConsumerConfig{AckWait: 22 * time.Hour, MaxAckPending: 10},
+	}, nil)
+
+	// When
+	opts, err := config.GetSubscribeOptions(s.logger, s.js, _testDurable, true)
+
+	// Then
+	s.Require().NoError(err)
+	s.Len(opts, 2)
+	s.js.AssertNotCalled(s.T(), "UpdateConsumer")
+}
+
+func (s *ConsumerConfigTestSuite) TestGetDeliverConfig_WhenResume_ExpectStartAfterAckFloor() {
+	// Given
+	config := s.getResumeConfig()
+	s.js.On("ConsumerInfo", _testStream, "previous-durable").
+		Return(&nats.ConsumerInfo{AckFloor: nats.SequenceInfo{Stream: 41}}, nil)
+
+	// When
+	deliverConfig, err := config.GetDeliverConfig(s.logger, s.js, _testStream)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(nats.DeliverByStartSequencePolicy, deliverConfig.DeliverPolicy)
+	s.Equal(uint64(42), deliverConfig.OptStartSeq)
+}
+
+func (s *ConsumerConfigTestSuite) TestGetDeliverConfig_WhenResumeWithoutAcks_ExpectPreviousConsumerStart() {
+	// Given
+	config := s.getResumeConfig()
+	s.js.On("ConsumerInfo", _testStream, "previous-durable").Return(&nats.ConsumerInfo{
+		Config:    nats.ConsumerConfig{DeliverPolicy: nats.DeliverByStartSequencePolicy, OptStartSeq: 100},
+		Delivered: nats.SequenceInfo{Stream: 120},
+	}, nil)
+
+	// When
+	deliverConfig, err := config.GetDeliverConfig(s.logger, s.js, _testStream)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(nats.DeliverByStartSequencePolicy, deliverConfig.DeliverPolicy)
+	s.Equal(uint64(100), deliverConfig.OptStartSeq)
+}
+
+func (s *ConsumerConfigTestSuite) TestGetDeliverConfig_WhenResumeWithoutAcksFromNewMessages_ExpectDeliverNew() {
+	// Given
+	config := s.getResumeConfig()
+	s.js.On("ConsumerInfo", _testStream, "previous-durable").Return(&nats.ConsumerInfo{
+		Config: nats.ConsumerConfig{DeliverPolicy: nats.DeliverNewPolicy},
+	}, nil)
+
+	// When
+	deliverConfig, err := config.GetDeliverConfig(s.logger, s.js, _testStream)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(nats.DeliverNewPolicy, deliverConfig.DeliverPolicy)
+	s.Zero(deliverConfig.OptStartSeq)
+}
+
+func (s *ConsumerConfigTestSuite) TestGetDeliverConfig_WhenResumeFromMissingConsumer_ExpectDeliverNew() {
+	// Given
+	config := s.getResumeConfig()
+	s.js.On("ConsumerInfo", _testStream, "previous-durable").Return(nil, nats.ErrConsumerNotFound)
+
+	// When
+	deliverConfig, err := config.GetDeliverConfig(s.logger, s.js, _testStream)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(nats.DeliverNewPolicy, deliverConfig.DeliverPolicy)
+}
+
+func (s *ConsumerConfigTestSuite) TestGetDeliverConfig_WhenByStartTime_ExpectStartTime() {
+	// Given
+	viper.Set(internalCommon.ConfigRunnerSubscriberConsumersKey, []map[string]interface{}{
+		{"subject": _testSubject, "deliver_policy": "by_start_time", "start_time": "2024-01-02T03:04:05Z"},
+	})
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+
+	// When
+	deliverConfig, err := config.GetDeliverConfig(s.logger, s.js, _testStream)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(nats.DeliverByStartTimePolicy, deliverConfig.DeliverPolicy)
+	s.Require().NotNil(deliverConfig.OptStartTime)
+	s.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), deliverConfig.OptStartTime.UTC())
+}
+
+func (s *ConsumerConfigTestSuite) TestGetSubscribeOptions_WhenResume_ExpectPreviousConsumerInfo() {
+	// Given
+	config := s.getResumeConfig()
+	s.js.On("ConsumerInfo", _testStream, _testDurable).Return(nil, nats.ErrConsumerNotFound)
+	s.js.On("ConsumerInfo", _testStream, "previous-durable").
+		Return(&nats.ConsumerInfo{AckFloor: nats.SequenceInfo{Stream: 41}}, nil)
+
+	// When
+	opts, err := config.GetSubscribeOptions(s.logger, s.js, _testDurable, false)
+
+	// Then
+	s.Require().NoError(err)
+	s.Len(opts, 4)
+	s.js.AssertNumberOfCalls(s.T(), "ConsumerInfo", 2)
+}
+
+func (s *ConsumerConfigTestSuite) TestGetSubscribeOptions_WhenConsumerInfoFails_ExpectError() {
+	// Given
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+	s.js.On("ConsumerInfo", _testStream, _testDurable).Return(nil, errors.New("connection lost"))
+
+	// When
+	_, err = config.GetSubscribeOptions(s.logger, s.js, _testDurable, false)
+
+	// Then
+	s.Error(err)
+}
+
+func (s *ConsumerConfigTestSuite) getResumeConfig() common.ConsumerConfig {
+	viper.Set(internalCommon.ConfigRunnerSubscriberConsumersKey, []map[string]interface{}{
+		{"subject": _testSubject, "deliver_policy": "resume", "resume_from": "previous-durable"},
+	})
+
+	config, err := common.GetConsumerConfig(_testSubject)
+	s.Require().NoError(err)
+
+	return config
+}
+
+func TestConsumerConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsumerConfigTestSuite))
+}
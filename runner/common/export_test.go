@@ -0,0 +1,32 @@
+//go:build unit
+
+package common
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	kaisdk "github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+func NewTestPublisher(kaiSDK kaisdk.KaiSDK, js nats.JetStreamContext, utils publisherUtils) *Publisher {
+	runnerMetrics, _ := metrics.NewRunner(noop.NewMeterProvider().Meter("test"))
+
+	return newPublisher(kaiSDK, js, utils, runnerMetrics)
+}
+
+func NewTestProcessor(kaiSDK kaisdk.KaiSDK, js nats.JetStreamContext, utils publisherUtils,
+	handlers map[string]Handler, ack AckFunc, nak NakFunc,
+) *Processor {
+	runnerMetrics, _ := metrics.NewRunner(noop.NewMeterProvider().Meter("test"))
+
+	return NewProcessor(kaiSDK, runnerMetrics, newPublisher(kaiSDK, js, utils, runnerMetrics), handlers, ack, nak)
+}
+
+func (c ConsumerConfig) GetDeliverConfig(logger logr.Logger, js nats.JetStreamContext,
+	stream string,
+) (nats.ConsumerConfig, error) {
+	return c.getDeliverConfig(logger, js, stream)
+}
@@ -0,0 +1,211 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/konstellation-io/kai-gosdk/internal/errors"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	"github.com/konstellation-io/kai-gosdk/internal/tracing"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	kaisdk "github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+const _defaultHandlerKey = "default"
+
+// AckFunc acknowledges a message received from the stream.
+type AckFunc func(msg *nats.Msg) error
+
+// NakFunc negatively acknowledges a message received from the stream, to be redelivered after the given delay.
+type NakFunc func(msg *nats.Msg, delay time.Duration) error
+
+func AckMsg(msg *nats.Msg) error {
+	return msg.Ack()
+}
+
+func NakMsg(msg *nats.Msg, delay time.Duration) error {
+	return msg.NakWithDelay(delay)
+}
+
+// Processor processes the requests that task and exit runners receive from the stream. The handler of the origin
+// node runs between the optional preprocessor and postprocessor, and an error response is published when any of
+// them fails.
+type Processor struct {
+	sdk           kaisdk.KaiSDK
+	metrics       *metrics.Runner
+	publisher     *Publisher
+	handlers      map[string]Handler
+	preprocessor  Handler
+	postprocessor Handler
+	ack           AckFunc
+	nak           NakFunc
+}
+
+// NewProcessor creates a processor running the given handlers by origin node, the "default" one for the nodes
+// without their own.
+func NewProcessor(kaiSDK kaisdk.KaiSDK, runnerMetrics *metrics.Runner, publisher *Publisher,
+	handlers map[string]Handler, ack AckFunc, nak NakFunc,
+) *Processor {
+	return &Processor{
+		sdk:       kaiSDK,
+		metrics:   runnerMetrics,
+		publisher: publisher,
+		handlers:  handlers,
+		ack:       ack,
+		nak:       nak,
+	}
+}
+
+func (p *Processor) WithPreprocessor(preprocessor Handler) *Processor {
+	p.preprocessor = preprocessor
+	return p
+}
+
+func (p *Processor) WithPostprocessor(postprocessor Handler) *Processor {
+	p.postprocessor = postprocessor
+	return p
+}
+
+func (p *Processor) getLoggerWithName() logr.Logger {
+	return p.sdk.Logger.WithName(_publisherLoggerName)
+}
+
+// ProcessMessage parses and processes a message received from the stream.
+func (p *Processor) ProcessMessage(msg *nats.Msg) {
+	requestMsg, ok := p.ParseMessage(msg)
+	if !ok {
+		return
+	}
+
+	p.ProcessRequest(msg, requestMsg)
+}
+
+// ParseMessage parses a message received from the stream and records its reception. Messages that are not valid
+// are acknowledged and answered with an error, and reported as not ok.
+func (p *Processor) ParseMessage(msg *nats.Msg) (*kai.KaiNatsMessage, bool) {
+	requestMsg, err := NewRequestMessage(msg.Data)
+	if err != nil {
+		p.metrics.RecordReceived(context.Background(), msg, nil, metrics.Attributes(p.sdk.Metadata, msg.Subject, ""))
+
+		errMsg := fmt.Sprintf("Error parsing msg.data coming from subject %s because is not a valid protobuf: %s",
+			msg.Subject, err)
+		p.ProcessError(msg, errMsg, requestMsg)
+
+		return nil, false
+	}
+
+	p.metrics.RecordReceived(context.Background(), msg, requestMsg,
+		metrics.Attributes(p.sdk.Metadata, msg.Subject, requestMsg.GetFromNode()))
+
+	return requestMsg, true
+}
+
+// ProcessRequest runs the handlers on the request, acknowledging the message once done.
+func (p *Processor) ProcessRequest(msg *nats.Msg, requestMsg *kai.KaiNatsMessage) {
+	attrs := metrics.Attributes(p.sdk.Metadata, msg.Subject, requestMsg.GetFromNode())
+
+	p.metrics.MessagesInFlight.Add(context.Background(), 1, attrs)
+
+	start := time.Now()
+	defer func() {
+		executionTime := time.Since(start).Milliseconds()
+		p.sdk.Logger.WithValues(kaisdk.LoggerRequestID, requestMsg.GetRequestId()).V(1).
+			Info(fmt.Sprintf("%s execution time: %d ms", p.sdk.Metadata.GetProcess(), executionTime))
+
+		p.metrics.ProcessingTime.Record(context.Background(), executionTime, attrs)
+		p.metrics.MessagesInFlight.Add(context.Background(), -1, attrs)
+	}()
+
+	p.getLoggerWithName().Info(fmt.Sprintf("New message received with subject %s",
+		msg.Subject))
+
+	handler := p.getHandler(strings.ToLower(requestMsg.GetFromNode()))
+	if handler == nil {
+		errMsg := fmt.Sprintf("Error missing handler for node %q", requestMsg.GetFromNode())
+		p.ProcessError(msg, errMsg, requestMsg)
+
+		return
+	}
+
+	// Make a shallow copy of the sdk object to set inside the request msg.
+	hSdk := kaisdk.ShallowCopyWithMsg(&p.sdk, msg, requestMsg)
+	defer tracing.EndSpan(hSdk.GetContext())
+
+	if p.preprocessor != nil {
+		err := p.preprocessor(hSdk, requestMsg.GetPayload())
+		if err != nil {
+			errMsg := fmt.Sprintf("Error in node %q executing handler preprocessor for node %q: %s",
+				p.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
+			p.ProcessError(msg, errMsg, requestMsg)
+
+			return
+		}
+	}
+
+	err := handler(hSdk, requestMsg.GetPayload())
+	if err != nil {
+		errMsg := fmt.Sprintf("Error in node %q executing handler for node %q: %s",
+			p.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
+		p.ProcessError(msg, errMsg, requestMsg)
+
+		return
+	}
+
+	if p.postprocessor != nil {
+		err := p.postprocessor(hSdk, requestMsg.GetPayload())
+		if err != nil {
+			errMsg := fmt.Sprintf("Error in node %q executing handler postprocessor for node %q: %s",
+				p.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
+			p.ProcessError(msg, errMsg, requestMsg)
+
+			return
+		}
+	}
+
+	p.metrics.MessagesProcessed.Add(context.Background(), 1, attrs)
+
+	// Tell NATS we don't need to receive the message anymore, and we are done processing it.
+	p.AckMessage(msg, attrs)
+}
+
+// ProcessError acknowledges a message that failed, publishing an error response for its request.
+func (p *Processor) ProcessError(msg *nats.Msg, errMsg string, requestMsg *kai.KaiNatsMessage) {
+	attrs := metrics.Attributes(p.sdk.Metadata, msg.Subject, requestMsg.GetFromNode())
+
+	p.metrics.MessagesFailed.Add(context.Background(), 1, attrs)
+	p.AckMessage(msg, attrs)
+
+	p.getLoggerWithName().V(1).Info(errMsg)
+	p.publisher.PublishError(requestMsg, requestMsg.GetReplySubject(), errMsg)
+}
+
+func (p *Processor) AckMessage(msg *nats.Msg, attrs metric.MeasurementOption) {
+	ackErr := p.ack(msg)
+	if ackErr != nil {
+		p.getLoggerWithName().Error(ackErr, errors.ErrMsgAck)
+		p.metrics.AckFailures.Add(context.Background(), 1, attrs)
+	}
+}
+
+func (p *Processor) NakMessage(msg *nats.Msg, delay time.Duration, attrs metric.MeasurementOption) {
+	nakErr := p.nak(msg, delay)
+	if nakErr != nil {
+		p.getLoggerWithName().Error(nakErr, errors.ErrMsgNak)
+		p.metrics.AckFailures.Add(context.Background(), 1, attrs)
+	}
+}
+
+func (p *Processor) getHandler(subject string) Handler {
+	if handler, ok := p.handlers[subject]; ok {
+		return handler
+	}
+
+	// returns the default handler, or nil if it doesn't exist
+	return p.handlers[_defaultHandlerKey]
+}
@@ -0,0 +1,226 @@
+//go:build unit
+
+package common_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	internalCommon "github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+var errHandler = errors.New("handler error")
+
+type ProcessorTestSuite struct {
+	suite.Suite
+	jetstream      *mocks.JetStreamContextMock
+	messagingUtils *mocks.MessagingUtilsMock
+	kaiSDK         sdk.KaiSDK
+	acked          []*nats.Msg
+	naked          []*nats.Msg
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, new(ProcessorTestSuite))
+}
+
+func (s *ProcessorTestSuite) SetupTest() {
+	viper.Reset()
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("../../testdata")
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("fatal error initializing configuration: %w", err))
+	}
+
+	viper.Set(internalCommon.ConfigMetadataProcessTypeKey, internalCommon.ProcessTypeExit)
+	viper.Set(internalCommon.ConfigMetadataProcessIDKey, _processID)
+
+	s.jetstream = mocks.NewJetStreamContextMock(s.T())
+	s.jetstream.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.jetstream.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+	s.messagingUtils = mocks.NewMessagingUtilsMock(s.T())
+
+	s.kaiSDK = sdk.NewKaiSDK(testr.New(s.T()), nil, s.jetstream)
+	s.acked = nil
+	s.naked = nil
+}
+
+func (s *ProcessorTestSuite) newProcessor(handlers map[string]common.Handler) *common.Processor {
+	return common.NewTestProcessor(s.kaiSDK, s.jetstream, s.messagingUtils, handlers,
+		func(msg *nats.Msg) error {
+			s.acked = append(s.acked, msg)
+			return nil
+		},
+		func(msg *nats.Msg, _ time.Duration) error {
+			s.naked = append(s.naked, msg)
+			return nil
+		})
+}
+
+func (s *ProcessorTestSuite) newMsg(fromNode, replySubject string) *nats.Msg {
+	payload, err := anypb.New(wrapperspb.String("some-request"))
+	s.Require().NoError(err)
+
+	data, err := proto.Marshal(&kai.KaiNatsMessage{
+		RequestId:    "123",
+		Payload:      payload,
+		FromNode:     fromNode,
+		ReplySubject: replySubject,
+	})
+	s.Require().NoError(err)
+
+	return &nats.Msg{Subject: "test-stream.task", Data: data}
+}
+
+func (s *ProcessorTestSuite) TestProcessMessage_HandlerSucceeds_ExpectAckedWithoutError() {
+	// Given
+	var received string
+
+	processor := s.newProcessor(map[string]common.Handler{
+		"default": func(_ sdk.KaiSDK, response *anypb.Any) error {
+			value := &wrapperspb.StringValue{}
+			s.Require().NoError(response.UnmarshalTo(value))
+			received = value.GetValue()
+
+			return nil
+		},
+	})
+	msg := s.newMsg("task", _replySubject)
+
+	// When
+	processor.ProcessMessage(msg)
+
+	// Then
+	s.Equal("some-request", received)
+	s.Equal([]*nats.Msg{msg}, s.acked)
+	s.Empty(s.naked)
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
+
+func (s *ProcessorTestSuite) TestProcessMessage_CustomHandler_ExpectRunForItsNode() {
+	// Given
+	var handled []string
+
+	processor := s.newProcessor(map[string]common.Handler{
+		"default": func(sdk.KaiSDK, *anypb.Any) error {
+			handled = append(handled, "default")
+			return nil
+		},
+		"task": func(sdk.KaiSDK, *anypb.Any) error {
+			handled = append(handled, "task")
+			return nil
+		},
+	})
+
+	// When
+	processor.ProcessMessage(s.newMsg("Task", _replySubject))
+	processor.ProcessMessage(s.newMsg("other-task", _replySubject))
+
+	// Then
+	s.Equal([]string{"task", "default"}, handled)
+	s.Len(s.acked, 2)
+}
+
+func (s *ProcessorTestSuite) TestProcessMessage_HandlerFails_ExpectErrorReplied() {
+	// Given
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024), nil)
+	s.messagingUtils.On("PublishMsg", mock.AnythingOfType("*nats.Msg")).Return(nil)
+
+	processor := s.newProcessor(map[string]common.Handler{
+		"default": func(sdk.KaiSDK, *anypb.Any) error {
+			return errHandler
+		},
+	})
+	msg := s.newMsg("task", _replySubject)
+
+	// When
+	processor.ProcessMessage(msg)
+
+	// Then
+	s.Equal([]*nats.Msg{msg}, s.acked)
+	s.Empty(s.naked)
+
+	errMsg := fmt.Sprintf("Error in node %q executing handler for node %q: %s",
+		s.kaiSDK.Metadata.GetProcess(), "task", errHandler)
+	s.messagingUtils.AssertCalled(s.T(), "PublishMsg", &nats.Msg{
+		Subject: _replySubject,
+		Data:    getErrorMessage("123", errMsg, _replySubject),
+	})
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+}
+
+func (s *ProcessorTestSuite) TestProcessMessage_MissingHandler_ExpectErrorReplied() {
+	// Given
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024), nil)
+	s.messagingUtils.On("PublishMsg", mock.AnythingOfType("*nats.Msg")).Return(nil)
+
+	processor := s.newProcessor(map[string]common.Handler{})
+	msg := s.newMsg("task", _replySubject)
+
+	// When
+	processor.ProcessMessage(msg)
+
+	// Then
+	s.Equal([]*nats.Msg{msg}, s.acked)
+	s.messagingUtils.AssertCalled(s.T(), "PublishMsg", &nats.Msg{
+		Subject: _replySubject,
+		Data:    getErrorMessage("123", `Error missing handler for node "task"`, _replySubject),
+	})
+}
+
+func (s *ProcessorTestSuite) TestProcessMessage_HandlerFailsWithoutReplySubject_ExpectErrorDiscarded() {
+	// Given
+	processor := s.newProcessor(map[string]common.Handler{
+		"default": func(sdk.KaiSDK, *anypb.Any) error {
+			return errHandler
+		},
+	})
+	msg := s.newMsg("task", "")
+
+	// When
+	processor.ProcessMessage(msg)
+
+	// Then
+	s.Equal([]*nats.Msg{msg}, s.acked)
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+}
+
+func (s *ProcessorTestSuite) TestProcessMessage_InvalidProtobuf_ExpectAckedWithoutHandling() {
+	// Given
+	handled := false
+
+	processor := s.newProcessor(map[string]common.Handler{
+		"default": func(sdk.KaiSDK, *anypb.Any) error {
+			handled = true
+			return nil
+		},
+	})
+	msg := &nats.Msg{Subject: "test-stream.task", Data: []byte("not a protobuf")}
+
+	// When
+	processor.ProcessMessage(msg)
+
+	// Then
+	s.False(handled)
+	s.Equal([]*nats.Msg{msg}, s.acked)
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
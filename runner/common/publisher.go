@@ -0,0 +1,184 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/errors"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	kaisdk "github.com/konstellation-io/kai-gosdk/sdk"
+	"github.com/konstellation-io/kai-gosdk/sdk/messaging"
+)
+
+const _publisherLoggerName = "[SUBSCRIBER]"
+
+type publisherUtils interface {
+	GetMaxMessageSize() (int64, error)
+	PublishMsg(msg *nats.Msg) error
+}
+
+// Publisher publishes the responses of a runner to its output subject.
+type Publisher struct {
+	sdk       kaisdk.KaiSDK
+	jetstream nats.JetStreamContext
+	utils     publisherUtils
+	metrics   *metrics.Runner
+}
+
+func NewPublisher(kaiSDK kaisdk.KaiSDK, ns *nats.Conn, js nats.JetStreamContext,
+	runnerMetrics *metrics.Runner,
+) *Publisher {
+	return newPublisher(kaiSDK, js, messaging.NewMessagingUtils(ns, js), runnerMetrics)
+}
+
+func newPublisher(kaiSDK kaisdk.KaiSDK, js nats.JetStreamContext, utils publisherUtils,
+	runnerMetrics *metrics.Runner,
+) *Publisher {
+	return &Publisher{
+		sdk:       kaiSDK,
+		jetstream: js,
+		utils:     utils,
+		metrics:   runnerMetrics,
+	}
+}
+
+// NewRequestMessage parses the data of a message received, uncompressing it if needed.
+func NewRequestMessage(data []byte) (*kai.KaiNatsMessage, error) {
+	requestMsg := &kai.KaiNatsMessage{}
+
+	var err error
+	if common.IsCompressed(data) {
+		data, err = common.UncompressData(data)
+		if err != nil {
+			return nil, fmt.Errorf("error reading compressed message: %w", err)
+		}
+	}
+
+	err = proto.Unmarshal(data, requestMsg)
+
+	return requestMsg, err
+}
+
+func (p *Publisher) getLoggerWithName() logr.Logger {
+	return p.sdk.Logger.WithName(_publisherLoggerName)
+}
+
+// PublishError publishes an error message for the request, to be routed to the given reply subject.
+func (p *Publisher) PublishError(requestMsg *kai.KaiNatsMessage, replySubject, errMsg string) {
+	responseMsg := &kai.KaiNatsMessage{
+		RequestId:    requestMsg.GetRequestId(),
+		Error:        errMsg,
+		FromNode:     viper.GetString(common.ConfigMetadataProcessIDKey),
+		MessageType:  kai.MessageType_ERROR,
+		ReplySubject: replySubject,
+	}
+	p.PublishResponse(responseMsg, "")
+}
+
+// PublishResponse publishes the message to the output subject of the channel. Exit processes publish the messages
+// straight to their reply subject over core NATS, back to the trigger replica waiting for them, and discard the
+// messages without one.
+func (p *Publisher) PublishResponse(responseMsg *kai.KaiNatsMessage, channel string) {
+	replySubject := responseMsg.GetReplySubject()
+
+	// Exit processes have no output subject, the responses without a reply subject can't be routed anywhere.
+	if isExitProcess() && replySubject == "" {
+		p.getLoggerWithName().Error(errors.ErrMissingReplySubject,
+			fmt.Sprintf("Discarding response for request id %s", responseMsg.GetRequestId()))
+		return
+	}
+
+	outputSubject := getOutputSubject(channel)
+
+	outputMsg, err := proto.Marshal(responseMsg)
+	if err != nil {
+		p.getLoggerWithName().
+			Error(err, "Error generating output result because handler result is not a serializable Protobuf")
+		return
+	}
+
+	outputMsg, err = p.prepareOutputMessage(outputMsg)
+	if err != nil {
+		p.getLoggerWithName().Error(err, "Error preparing output message")
+		return
+	}
+
+	// Reply subjects are unique per trigger replica, the output subject is used as attribute instead.
+	attrs := metrics.Attributes(p.sdk.Metadata, outputSubject, responseMsg.GetFromNode())
+	p.metrics.RecordPublished(context.Background(), int64(proto.Size(responseMsg)), outputMsg, attrs)
+
+	start := time.Now()
+
+	if isExitProcess() {
+		p.getLoggerWithName().V(1).Info(fmt.Sprintf("Publishing response with reply subject %s", replySubject))
+
+		err = p.utils.PublishMsg(&nats.Msg{Subject: replySubject, Data: outputMsg})
+	} else {
+		p.getLoggerWithName().V(1).Info(fmt.Sprintf("Publishing response with subject %s", outputSubject))
+
+		_, err = p.jetstream.Publish(outputSubject, outputMsg)
+	}
+
+	if err != nil {
+		p.getLoggerWithName().Error(err, "Error publishing output")
+	}
+
+	p.metrics.PublishLatency.Record(context.Background(), time.Since(start).Milliseconds(), attrs)
+}
+
+func isExitProcess() bool {
+	return viper.GetString(common.ConfigMetadataProcessTypeKey) == common.ProcessTypeExit
+}
+
+func getOutputSubject(channel string) string {
+	outputSubject := viper.GetString(common.ConfigNatsOutputKey)
+	if channel != "" {
+		return fmt.Sprintf("%s.%s", outputSubject, channel)
+	}
+
+	return outputSubject
+}
+
+// prepareOutputMessage will check the length of the message and compress it if necessary.
+// Fails on compressed messages bigger than the threshold.
+func (p *Publisher) prepareOutputMessage(msg []byte) ([]byte, error) {
+	maxSize, err := p.utils.GetMaxMessageSize()
+	if err != nil {
+		return nil, fmt.Errorf("error getting max message size: %w", err)
+	}
+
+	lenMsg := int64(len(msg))
+	if lenMsg <= maxSize {
+		return msg, nil
+	}
+
+	outMsg, err := common.CompressData(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	lenOutMsg := int64(len(outMsg))
+	if lenOutMsg > maxSize {
+		p.getLoggerWithName().V(1).Info(fmt.Sprintf("Compressed message size %s "+
+			"exceeds maximum size allowed %s", sizeInMB(lenOutMsg), sizeInMB(maxSize)))
+		return nil, errors.ErrMessageToBig
+	}
+
+	p.getLoggerWithName().Info(fmt.Sprintf("Message prepared with original size %s "+
+		"and compressed size %s", sizeInMB(lenMsg), sizeInMB(lenOutMsg)))
+
+	return outMsg, nil
+}
+
+func sizeInMB(size int64) string {
+	mbSize := float32(size) / 1024 / 1024
+	return fmt.Sprintf("%.1f MB", mbSize)
+}
@@ -0,0 +1,158 @@
+//go:build unit
+
+package common_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	internalCommon "github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
+)
+
+const (
+	_outputSubject = "test-parent"
+	_replySubject  = "_INBOX.replica"
+	_processID     = "exit-node"
+)
+
+type PublisherTestSuite struct {
+	suite.Suite
+	jetstream      *mocks.JetStreamContextMock
+	messagingUtils *mocks.MessagingUtilsMock
+	publisher      *common.Publisher
+}
+
+func TestPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(PublisherTestSuite))
+}
+
+func (s *PublisherTestSuite) SetupTest() {
+	// Reset viper values before each test
+	viper.Reset()
+	viper.Set(internalCommon.ConfigNatsOutputKey, _outputSubject)
+	viper.Set(internalCommon.ConfigMetadataProcessIDKey, _processID)
+
+	s.jetstream = mocks.NewJetStreamContextMock(s.T())
+	s.messagingUtils = mocks.NewMessagingUtilsMock(s.T())
+
+	kaiSDK := sdk.KaiSDK{
+		Logger:   testr.NewWithOptions(s.T(), testr.Options{Verbosity: 1}),
+		Metadata: metadata.New(),
+	}
+
+	s.publisher = common.NewTestPublisher(kaiSDK, s.jetstream, s.messagingUtils)
+}
+
+func (s *PublisherTestSuite) TestPublishError_ExitProcessWithReplySubject_ExpectPublishedToReplySubject() {
+	// Given
+	viper.Set(internalCommon.ConfigMetadataProcessTypeKey, internalCommon.ProcessTypeExit)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024), nil)
+	s.messagingUtils.On("PublishMsg", mock.AnythingOfType("*nats.Msg")).Return(nil)
+
+	// When
+	s.publisher.PublishError(&kai.KaiNatsMessage{RequestId: "123"}, _replySubject, "some-error")
+
+	// Then
+	s.messagingUtils.AssertCalled(s.T(), "PublishMsg", &nats.Msg{
+		Subject: _replySubject,
+		Data:    getErrorMessage("123", "some-error", _replySubject),
+	})
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+}
+
+func (s *PublisherTestSuite) TestPublishError_ExitProcessWithoutReplySubject_ExpectDiscarded() {
+	// Given
+	viper.Set(internalCommon.ConfigMetadataProcessTypeKey, internalCommon.ProcessTypeExit)
+
+	// When
+	s.publisher.PublishError(&kai.KaiNatsMessage{RequestId: "123"}, "", "some-error")
+
+	// Then
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
+
+func (s *PublisherTestSuite) TestPublishError_TaskProcessWithReplySubject_ExpectPublishedToStream() {
+	// Given
+	viper.Set(internalCommon.ConfigMetadataProcessTypeKey, internalCommon.ProcessTypeTask)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024), nil)
+	s.jetstream.On("Publish", _outputSubject, mock.AnythingOfType("[]uint8")).Return(&nats.PubAck{}, nil)
+
+	// When
+	s.publisher.PublishError(&kai.KaiNatsMessage{RequestId: "123"}, _replySubject, "some-error")
+
+	// Then
+	s.jetstream.AssertCalled(s.T(), "Publish", _outputSubject, getErrorMessage("123", "some-error", _replySubject))
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
+
+func (s *PublisherTestSuite) TestPublishResponse_ToChannel_ExpectPublishedToChannelSubject() {
+	// Given
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024), nil)
+	s.jetstream.On("Publish", "test-parent.channel", mock.AnythingOfType("[]uint8")).Return(&nats.PubAck{}, nil)
+
+	// When
+	s.publisher.PublishResponse(&kai.KaiNatsMessage{RequestId: "123"}, "channel")
+
+	// Then
+	s.jetstream.AssertNumberOfCalls(s.T(), "Publish", 1)
+}
+
+func (s *PublisherTestSuite) TestPublishResponse_CompressedMessageTooBig_ExpectNotPublished() {
+	// Given
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(16), nil)
+
+	// When
+	s.publisher.PublishError(&kai.KaiNatsMessage{RequestId: "123"}, "", "an error message longer than the maximum size")
+
+	// Then
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
+
+func (s *PublisherTestSuite) TestNewRequestMessage_CompressedData_ExpectUncompressed() {
+	// Given
+	data, err := proto.Marshal(&kai.KaiNatsMessage{RequestId: "123"})
+	s.Require().NoError(err)
+
+	compressed, err := internalCommon.CompressData(data)
+	s.Require().NoError(err)
+
+	// When
+	requestMsg, err := common.NewRequestMessage(compressed)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal("123", requestMsg.GetRequestId())
+}
+
+func (s *PublisherTestSuite) TestNewRequestMessage_InvalidData_ExpectError() {
+	// When
+	_, err := common.NewRequestMessage([]byte("not a protobuf"))
+
+	// Then
+	s.Error(err)
+}
+
+func getErrorMessage(requestID, errMsg, replySubject string) []byte {
+	data, _ := proto.Marshal(&kai.KaiNatsMessage{
+		RequestId:    requestID,
+		Error:        errMsg,
+		FromNode:     _processID,
+		MessageType:  kai.MessageType_ERROR,
+		ReplySubject: replySubject,
+	})
+
+	return data
+}
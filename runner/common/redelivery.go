@@ -0,0 +1,31 @@
+package common
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const _maxRedeliveryDelay = time.Minute
+
+// NumDelivered returns how many times the message has been delivered, 1 for messages without JetStream metadata.
+func NumDelivered(msg *nats.Msg) uint64 {
+	msgMetadata, err := msg.Metadata()
+	if err != nil {
+		return 1
+	}
+
+	return msgMetadata.NumDelivered
+}
+
+// RedeliveryDelay returns how long to wait before redelivering a message delivered the given times, doubling the
+// base delay on every delivery up to a minute.
+func RedeliveryDelay(base time.Duration, numDelivered uint64) time.Duration {
+	delay := base
+
+	for i := uint64(1); i < numDelivered && delay < _maxRedeliveryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, _maxRedeliveryDelay)
+}
@@ -0,0 +1,40 @@
+//go:build unit
+
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/konstellation-io/kai-gosdk/runner/common"
+)
+
+func TestNumDelivered(t *testing.T) {
+	msg := nats.NewMsg("test-subject")
+	assert.Equal(t, uint64(1), common.NumDelivered(msg))
+
+	msg.Sub = &nats.Subscription{}
+	msg.Reply = "$JS.ACK.test-stream.test-consumer.4.10.10.1700000000000000000.0"
+	assert.Equal(t, uint64(4), common.NumDelivered(msg))
+}
+
+func TestRedeliveryDelay(t *testing.T) {
+	tests := []struct {
+		name         string
+		numDelivered uint64
+		want         time.Duration
+	}{
+		{name: "first delivery", numDelivered: 1, want: time.Second},
+		{name: "third delivery", numDelivered: 3, want: 4 * time.Second},
+		{name: "capped", numDelivered: 20, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, common.RedeliveryDelay(time.Second, tt.numDelivered))
+		})
+	}
+}
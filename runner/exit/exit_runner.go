@@ -0,0 +1,105 @@
+package exit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	runnerCommon "github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/runner/health"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+const _exitLoggerName = "[EXIT]"
+
+type Handler runnerCommon.Handler
+
+type Runner struct {
+	sdk              sdk.KaiSDK
+	nats             *nats.Conn
+	jetstream        nats.JetStreamContext
+	health           *health.Checker
+	responseHandlers map[string]runnerCommon.Handler
+	initializer      runnerCommon.Initializer
+	finalizer        runnerCommon.Finalizer
+	metrics          *metrics.Runner
+	publisher        *runnerCommon.Publisher
+	processor        *runnerCommon.Processor
+}
+
+func NewExitRunner(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContext) *Runner {
+	return &Runner{
+		sdk:              sdk.NewKaiSDK(logger.WithName(_exitLoggerName), ns, js),
+		nats:             ns,
+		jetstream:        js,
+		responseHandlers: make(map[string]runnerCommon.Handler),
+	}
+}
+
+// WithHealthChecker sets the checker serving the health endpoints, updated as the runner starts and stops.
+func (er *Runner) WithHealthChecker(hc *health.Checker) *Runner {
+	er.health = hc
+	return er
+}
+
+func (er *Runner) WithInitializer(initializer runnerCommon.Initializer) *Runner {
+	er.initializer = composeInitializer(initializer)
+	return er
+}
+
+func (er *Runner) WithHandler(handler Handler) *Runner {
+	er.responseHandlers["default"] = runnerCommon.Handler(composeHandler(handler))
+	return er
+}
+
+func (er *Runner) WithCustomHandler(subject string, handler Handler) *Runner {
+	er.responseHandlers[strings.ToLower(subject)] = runnerCommon.Handler(composeHandler(handler))
+	return er
+}
+
+func (er *Runner) WithFinalizer(finalizer runnerCommon.Finalizer) *Runner {
+	er.finalizer = composeFinalizer(finalizer)
+	return er
+}
+
+func (er *Runner) Run() {
+	if er.responseHandlers["default"] == nil {
+		panic("Undefined default handler")
+	}
+
+	// The exit process is the last node of the workflow, its outputs are routed back to the trigger.
+	if er.sdk.Metadata.GetProcessType() != common.ProcessTypeExit {
+		panic(fmt.Sprintf("Invalid process type %q for an exit runner, expected %q",
+			er.sdk.Metadata.GetProcessType(), common.ProcessTypeExit))
+	}
+
+	// Nothing can be downstream of the exit process, so it must not have an output subject.
+	if outputSubject := viper.GetString(common.ConfigNatsOutputKey); outputSubject != "" {
+		panic(fmt.Sprintf("Invalid output subject %q for an exit runner, the exit process must be the last node",
+			outputSubject))
+	}
+
+	if er.initializer == nil {
+		er.initializer = composeInitializer(nil)
+	}
+
+	if er.finalizer == nil {
+		er.finalizer = composeFinalizer(nil)
+	}
+
+	er.health.Start()
+
+	er.initializer(er.sdk)
+	er.health.SetInitialized()
+
+	er.startSubscriber()
+
+	er.finalizer(er.sdk)
+
+	er.health.Shutdown()
+}
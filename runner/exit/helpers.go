@@ -0,0 +1,59 @@
+package exit
+
+import (
+	"github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	_initializerLoggerName = "[INITIALIZER]"
+	_handlerLoggerName     = "[HANDLER]"
+	_finalizerLoggerName   = "[FINALIZER]"
+)
+
+func composeInitializer(initializer common.Initializer) common.Initializer {
+	return func(kaiSDK sdk.KaiSDK) {
+		kaiSDK.Logger.WithName(_initializerLoggerName).V(1).Info("Initializing ExitRunner...")
+		common.InitializeProcessConfiguration(kaiSDK)
+		common.ValidateProcessConfiguration(kaiSDK)
+		common.StartMeasurements(kaiSDK)
+
+		if initializer != nil {
+			kaiSDK.Logger.WithName(_initializerLoggerName).V(3).Info("Executing user initializer...")
+			initializer(kaiSDK)
+			kaiSDK.Logger.WithName(_initializerLoggerName).V(3).Info("User initializer executed")
+		}
+
+		kaiSDK.Logger.WithName(_initializerLoggerName).V(1).Info("ExitRunner initialized")
+	}
+}
+
+func composeHandler(handler Handler) Handler {
+	return func(kaiSDK sdk.KaiSDK, response *anypb.Any) error {
+		kaiSDK.Logger.WithName(_handlerLoggerName).V(1).Info("Handling ExitRunner...")
+
+		if handler != nil {
+			kaiSDK.Logger.WithName(_handlerLoggerName).V(3).Info("Executing user handler...")
+			return handler(kaiSDK, response)
+		}
+
+		return nil
+	}
+}
+
+func composeFinalizer(finalizer common.Finalizer) common.Finalizer {
+	return func(kaiSDK sdk.KaiSDK) {
+		kaiSDK.Logger.WithName(_finalizerLoggerName).V(1).Info("Finalizing ExitRunner...")
+
+		if finalizer != nil {
+			kaiSDK.Logger.WithName(_finalizerLoggerName).V(3).Info("Executing user finalizer...")
+			finalizer(kaiSDK)
+			kaiSDK.Logger.WithName(_finalizerLoggerName).V(3).Info("User finalizer executed")
+		}
+
+		common.ShutdownMeasurements(kaiSDK)
+
+		kaiSDK.Logger.WithName(_finalizerLoggerName).V(1).Info("ExitRunner finalized")
+	}
+}
@@ -0,0 +1,105 @@
+package exit
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	runnerCommon "github.com/konstellation-io/kai-gosdk/runner/common"
+)
+
+const _subscriberLoggerName = "[SUBSCRIBER]"
+
+func (er *Runner) getLoggerWithName() logr.Logger {
+	return er.sdk.Logger.WithName(_subscriberLoggerName)
+}
+
+func (er *Runner) startSubscriber() {
+	inputSubjects := viper.GetStringSlice(common.ConfigNatsInputsKey)
+
+	if len(inputSubjects) == 0 {
+		er.getLoggerWithName().Info("Undefined input subjects")
+		os.Exit(1)
+	}
+
+	var err error
+
+	er.metrics, err = metrics.NewRunner(er.sdk.Measurements.GetMetricsClient())
+	if err != nil {
+		er.getLoggerWithName().Error(err, "Error initializing metrics")
+		os.Exit(1)
+	}
+
+	er.publisher = runnerCommon.NewPublisher(er.sdk, er.nats, er.jetstream, er.metrics)
+	er.processor = runnerCommon.NewProcessor(er.sdk, er.metrics, er.publisher, er.responseHandlers,
+		runnerCommon.AckMsg, runnerCommon.NakMsg)
+
+	subscriptions := make([]*nats.Subscription, 0, len(inputSubjects))
+
+	for _, subject := range inputSubjects {
+		consumerName := fmt.Sprintf("%s-%s", strings.ReplaceAll(subject, ".", "-"),
+			strings.ReplaceAll(strings.ReplaceAll(er.sdk.Metadata.GetProcess(), ".", "-"), " ", "-"))
+
+		er.getLoggerWithName().V(1).Info(fmt.Sprintf("Subscribing to subject %s with queue group %s", subject, consumerName))
+
+		s, err := er.subscribe(subject, consumerName)
+		if err != nil {
+			er.getLoggerWithName().Error(err, fmt.Sprintf("Error subscribing to subject %s", subject))
+			os.Exit(1)
+		}
+
+		subscriptions = append(subscriptions, s)
+
+		er.getLoggerWithName().V(1).Info(fmt.Sprintf("Listening to subject %s with queue group %s", subject, consumerName))
+	}
+
+	er.getLoggerWithName().V(1).Info("Subscribed to all subjects successfully")
+	er.health.SetSubscribed(true)
+
+	// Handle sigterm and await termChan signal
+	termChan := make(chan os.Signal, 1)
+	signal.Notify(termChan, syscall.SIGINT, syscall.SIGTERM)
+	<-termChan
+
+	// Handle shutdown
+	er.getLoggerWithName().Info("Shutdown signal received")
+	er.health.SetSubscribed(false)
+
+	er.getLoggerWithName().V(1).Info("Unsubscribing from all subjects")
+
+	for _, s := range subscriptions {
+		er.getLoggerWithName().V(1).Info(fmt.Sprintf("Unsubscribing from subject %s", s.Subject))
+
+		err := s.Unsubscribe()
+		if err != nil {
+			er.getLoggerWithName().Error(err, fmt.Sprintf("Error unsubscribing from the subject %s", s.Subject))
+			os.Exit(1)
+		}
+	}
+
+	er.getLoggerWithName().Info("Unsubscribed from all subjects")
+}
+
+func (er *Runner) subscribe(subject, consumerName string) (*nats.Subscription, error) {
+	consumerConfig, err := runnerCommon.GetConsumerConfig(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	durableName := consumerConfig.GetDurableName(consumerName)
+
+	opts, err := consumerConfig.GetSubscribeOptions(er.sdk.Logger, er.jetstream, durableName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return er.jetstream.QueueSubscribe(subject, durableName, er.processor.ProcessMessage, opts...)
+}
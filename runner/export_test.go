@@ -9,8 +9,10 @@ import (
 func NewTestRunner(ns *nats.Conn, js nats.JetStreamContext) *Runner {
 	initializeConfiguration()
 
+	logger, _ := getLogger()
+
 	return &Runner{
-		logger:    getLogger(),
+		logger:    logger,
 		nats:      ns,
 		jetstream: js,
 	}
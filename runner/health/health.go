@@ -0,0 +1,185 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+)
+
+var (
+	ErrNatsDisconnected = errors.New("the NATS connection is not established")
+	ErrNotInitialized   = errors.New("the runner initializer has not finished")
+	ErrNotSubscribed    = errors.New("the runner is not subscribed to its input subjects")
+)
+
+const (
+	_healthLoggerName  = "[HEALTH]"
+	_readHeaderTimeout = 5 * time.Second
+	_shutdownTimeout   = 5 * time.Second
+)
+
+// Checker serves the liveness, readiness and metrics endpoints of a runner.
+// A nil Checker is valid and does nothing, it is used when the health server is disabled.
+type Checker struct {
+	logger      logr.Logger
+	nats        *nats.Conn
+	jetstream   nats.JetStreamContext
+	server      *http.Server
+	initialized atomic.Bool
+	subscribed  atomic.Bool
+}
+
+// NewChecker returns a health checker, or nil when the health server is not enabled in the runner configuration.
+func NewChecker(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContext) *Checker {
+	if !viper.GetBool(common.ConfigRunnerHealthEnabledKey) {
+		return nil
+	}
+
+	return &Checker{
+		logger:    logger.WithName(_healthLoggerName),
+		nats:      ns,
+		jetstream: js,
+	}
+}
+
+// Handler routes the /healthz, /readyz and /metrics endpoints.
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", c.serveCheck(c.checkLiveness))
+	mux.HandleFunc("/readyz", c.serveCheck(c.checkReadiness))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+// Start serves the health endpoints in the background.
+func (c *Checker) Start() {
+	if c == nil {
+		return
+	}
+
+	c.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", viper.GetInt(common.ConfigRunnerHealthPortKey)),
+		Handler:           c.Handler(),
+		ReadHeaderTimeout: _readHeaderTimeout,
+	}
+
+	go func() {
+		c.logger.Info(fmt.Sprintf("Serving health endpoints on %s", c.server.Addr))
+
+		err := c.server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			c.logger.Error(err, "Error serving health endpoints")
+		}
+	}()
+}
+
+func (c *Checker) Shutdown() {
+	if c == nil || c.server == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _shutdownTimeout)
+	defer cancel()
+
+	err := c.server.Shutdown(ctx)
+	if err != nil {
+		c.logger.Error(err, "Error shutting down health endpoints")
+	}
+}
+
+// SetInitialized marks the runner initializer as finished.
+func (c *Checker) SetInitialized() {
+	if c == nil {
+		return
+	}
+
+	c.initialized.Store(true)
+}
+
+// SetSubscribed sets whether the runner is subscribed to its input subjects.
+func (c *Checker) SetSubscribed(subscribed bool) {
+	if c == nil {
+		return
+	}
+
+	c.subscribed.Store(subscribed)
+}
+
+func (c *Checker) serveCheck(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		err := check()
+		if err != nil {
+			c.logger.V(1).Info(fmt.Sprintf("Health check failed: %s", err))
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+func (c *Checker) checkLiveness() error {
+	if c.nats == nil || !c.nats.IsConnected() {
+		return ErrNatsDisconnected
+	}
+
+	return nil
+}
+
+func (c *Checker) checkReadiness() error {
+	if !c.initialized.Load() {
+		return ErrNotInitialized
+	}
+
+	if !c.subscribed.Load() {
+		return ErrNotSubscribed
+	}
+
+	err := c.checkLiveness()
+	if err != nil {
+		return err
+	}
+
+	return c.checkStores()
+}
+
+func (c *Checker) checkStores() error {
+	buckets := []string{
+		viper.GetString(common.ConfigCcGlobalBucketKey),
+		viper.GetString(common.ConfigCcProductBucketKey),
+		viper.GetString(common.ConfigCcWorkflowBucketKey),
+		viper.GetString(common.ConfigCcProcessBucketKey),
+	}
+
+	for _, bucket := range buckets {
+		_, err := c.jetstream.KeyValue(bucket)
+		if err != nil {
+			return fmt.Errorf("key-value store %s is not reachable: %w", bucket, err)
+		}
+	}
+
+	if viper.IsSet(common.ConfigNatsEphemeralStorage) {
+		bucket := viper.GetString(common.ConfigNatsEphemeralStorage)
+
+		_, err := c.jetstream.ObjectStore(bucket)
+		if err != nil {
+			return fmt.Errorf("object store %s is not reachable: %w", bucket, err)
+		}
+	}
+
+	return nil
+}
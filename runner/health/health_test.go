@@ -0,0 +1,127 @@
+//go:build unit
+
+package health_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	"github.com/konstellation-io/kai-gosdk/runner/health"
+)
+
+type HealthCheckerTestSuite struct {
+	suite.Suite
+	logger logr.Logger
+	js     *mocks.JetStreamContextMock
+}
+
+func (s *HealthCheckerTestSuite) SetupSuite() {
+	s.logger = testr.NewWithOptions(s.T(), testr.Options{Verbosity: 1})
+}
+
+func (s *HealthCheckerTestSuite) SetupTest() {
+	viper.Reset()
+	viper.Set(common.ConfigRunnerHealthEnabledKey, true)
+
+	s.js = mocks.NewJetStreamContextMock(s.T())
+}
+
+func (s *HealthCheckerTestSuite) serve(checker *health.Checker, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	checker.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return recorder
+}
+
+func (s *HealthCheckerTestSuite) TestNewChecker_WhenDisabled_ExpectNilSafeChecker() {
+	// Given
+	viper.Set(common.ConfigRunnerHealthEnabledKey, false)
+
+	// When
+	checker := health.NewChecker(s.logger, nil, s.js)
+
+	// Then
+	s.Nil(checker)
+	s.NotPanics(func() {
+		checker.Start()
+		checker.SetInitialized()
+		checker.SetSubscribed(true)
+		checker.Shutdown()
+	})
+}
+
+func (s *HealthCheckerTestSuite) TestLiveness_WhenNatsDisconnected_ExpectUnavailable() {
+	// Given
+	checker := health.NewChecker(s.logger, nil, s.js)
+
+	// When
+	response := s.serve(checker, "/healthz")
+
+	// Then
+	s.Equal(http.StatusServiceUnavailable, response.Code)
+	s.Contains(response.Body.String(), health.ErrNatsDisconnected.Error())
+}
+
+func (s *HealthCheckerTestSuite) TestReadiness_WhenNotInitialized_ExpectUnavailable() {
+	// Given
+	checker := health.NewChecker(s.logger, nil, s.js)
+
+	// When
+	response := s.serve(checker, "/readyz")
+
+	// Then
+	s.Equal(http.StatusServiceUnavailable, response.Code)
+	s.Contains(response.Body.String(), health.ErrNotInitialized.Error())
+}
+
+func (s *HealthCheckerTestSuite) TestReadiness_WhenNotSubscribed_ExpectUnavailable() {
+	// Given
+	checker := health.NewChecker(s.logger, nil, s.js)
+	checker.SetInitialized()
+	checker.SetSubscribed(true)
+	checker.SetSubscribed(false)
+
+	// When
+	response := s.serve(checker, "/readyz")
+
+	// Then
+	s.Equal(http.StatusServiceUnavailable, response.Code)
+	s.Contains(response.Body.String(), health.ErrNotSubscribed.Error())
+}
+
+func (s *HealthCheckerTestSuite) TestReadiness_WhenNatsDisconnected_ExpectUnavailable() {
+	// Given
+	checker := health.NewChecker(s.logger, nil, s.js)
+	checker.SetInitialized()
+	checker.SetSubscribed(true)
+
+	// When
+	response := s.serve(checker, "/readyz")
+
+	// Then
+	s.Equal(http.StatusServiceUnavailable, response.Code)
+	s.Contains(response.Body.String(), health.ErrNatsDisconnected.Error())
+}
+
+func (s *HealthCheckerTestSuite) TestMetrics_ExpectOK() {
+	// Given
+	checker := health.NewChecker(s.logger, nil, s.js)
+
+	// When
+	response := s.serve(checker, "/metrics")
+
+	// Then
+	s.Equal(http.StatusOK, response.Code)
+}
+
+func TestHealthCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckerTestSuite))
+}
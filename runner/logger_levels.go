@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/logging"
+)
+
+const _loggerLevelsLoggerName = "[LOGGER LEVELS]"
+
+// watchLoggerLevels applies the logger levels set in the process configuration while the process runs,
+// restoring the startup level when the key is deleted.
+func watchLoggerLevels(logger logr.Logger, js nats.JetStreamContext, levels *logging.Levels) {
+	key := viper.GetString(common.ConfigRunnerLoggerLevelConfigKey)
+
+	kv, err := js.KeyValue(viper.GetString(common.ConfigCcProcessBucketKey))
+	if err != nil {
+		logger.WithName(_loggerLevelsLoggerName).Error(err, "Error accessing the process configuration, "+
+			"logger levels cannot be changed at runtime")
+
+		return
+	}
+
+	watcher, err := kv.Watch(key)
+	if err != nil {
+		logger.WithName(_loggerLevelsLoggerName).Error(err, fmt.Sprintf("Error watching configuration key %s", key))
+		return
+	}
+
+	go func() {
+		for entry := range watcher.Updates() {
+			// A nil entry marks the end of the initial values.
+			if entry == nil {
+				continue
+			}
+
+			applyLoggerLevels(logger, levels, entry)
+		}
+	}()
+}
+
+func applyLoggerLevels(logger logr.Logger, levels *logging.Levels, entry nats.KeyValueEntry) {
+	if entry.Operation() != nats.KeyValuePut {
+		levels.Reset()
+		logger.WithName(_loggerLevelsLoggerName).Info("Logger levels restored")
+
+		return
+	}
+
+	err := levels.Set(string(entry.Value()))
+	if err != nil {
+		logger.WithName(_loggerLevelsLoggerName).Error(err, "Error changing logger levels")
+		return
+	}
+
+	logger.WithName(_loggerLevelsLoggerName).Info(fmt.Sprintf("Logger levels changed to %q", string(entry.Value())))
+}
@@ -5,27 +5,36 @@ import (
 	"time"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/logging"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
+	"github.com/konstellation-io/kai-gosdk/runner/exit"
+	"github.com/konstellation-io/kai-gosdk/runner/health"
 	"github.com/konstellation-io/kai-gosdk/runner/task"
 	"github.com/konstellation-io/kai-gosdk/runner/trigger"
 	"github.com/nats-io/nats.go"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/log/global"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"golang.org/x/exp/slices"
 )
 
+const _otelLoggerName = "github.com/konstellation-io/kai-gosdk"
+
 type Runner struct {
 	logger    logr.Logger
 	nats      *nats.Conn
 	jetstream nats.JetStreamContext
+	health    *health.Checker
 }
 
 func NewRunner() *Runner {
 	initializeConfiguration()
 
-	logger := getLogger()
+	logger, levels := getLogger()
 
 	nc, err := getNatsConnection(logger)
 	if err != nil {
@@ -37,10 +46,13 @@ func NewRunner() *Runner {
 		panic(fmt.Errorf("fatal error connecting to JetStream: %w", err))
 	}
 
+	watchLoggerLevels(logger, js, levels)
+
 	return &Runner{
 		logger:    logger,
 		nats:      nc,
 		jetstream: js,
+		health:    health.NewChecker(logger, nc, js),
 	}
 }
 
@@ -54,7 +66,6 @@ func validateConfig(keys []string) {
 		common.ConfigMetadataProcessTypeKey,
 		common.ConfigNatsURLKey,
 		common.ConfigNatsStreamKey,
-		common.ConfigNatsOutputKey,
 		common.ConfigCcGlobalBucketKey,
 		common.ConfigCcProductBucketKey,
 		common.ConfigCcWorkflowBucketKey,
@@ -72,10 +83,38 @@ func validateConfig(keys []string) {
 		common.ConfigRedisPasswordKey,
 		common.ConfigRedisEndpointKey,
 		common.ConfigRedisIndexKey,
-		common.ConfigMeasurementsEndpointKey,
-		common.ConfigMeasurementsInsecureKey,
-		common.ConfigMeasurementsTimeoutKey,
-		common.ConfigMeasurementsMetricsIntervalKey,
+	}
+
+	// The exit process is the last node of the workflow, its outputs are routed back to the trigger.
+	if viper.GetString(common.ConfigMetadataProcessTypeKey) != common.ProcessTypeExit {
+		mandatoryConfigKeys = append(mandatoryConfigKeys, common.ConfigNatsOutputKey)
+	}
+
+	// The exporter default is not set yet, an undefined exporter means OTLP gRPC.
+	switch viper.GetString(common.ConfigMeasurementsExporterKey) {
+	case "", common.MeasurementsExporterOtlpGrpc, common.MeasurementsExporterOtlpHTTP:
+		mandatoryConfigKeys = append(mandatoryConfigKeys,
+			common.ConfigMeasurementsEndpointKey,
+			common.ConfigMeasurementsInsecureKey,
+			common.ConfigMeasurementsTimeoutKey,
+			common.ConfigMeasurementsMetricsIntervalKey,
+		)
+	case common.MeasurementsExporterStdout:
+		mandatoryConfigKeys = append(mandatoryConfigKeys, common.ConfigMeasurementsMetricsIntervalKey)
+	case common.MeasurementsExporterPrometheus:
+		// Prometheus metrics are only scraped from the /metrics endpoint of the health server.
+		if !viper.GetBool(common.ConfigRunnerHealthEnabledKey) {
+			panic(fmt.Sprintf("the %s measurements exporter requires %s to be true",
+				common.MeasurementsExporterPrometheus, common.ConfigRunnerHealthEnabledKey))
+		}
+	}
+
+	if viper.GetBool(common.ConfigMeasurementsLogsEnabledKey) || viper.GetBool(common.ConfigMeasurementsTracesEnabledKey) {
+		mandatoryConfigKeys = append(mandatoryConfigKeys,
+			common.ConfigMeasurementsEndpointKey,
+			common.ConfigMeasurementsInsecureKey,
+			common.ConfigMeasurementsTimeoutKey,
+		)
 	}
 
 	for _, key := range mandatoryConfigKeys {
@@ -119,10 +158,29 @@ func initializeConfiguration() {
 
 	// Set viper default values
 	viper.SetDefault(common.ConfigRunnerSubscriberAckWaitTimeKey, 22*time.Hour)
+	viper.SetDefault(common.ConfigRunnerSubscriberFetchMaxWaitKey, 5*time.Second)
+	viper.SetDefault(common.ConfigRunnerSubscriberForwardWaitKey, 2*time.Second)
+	viper.SetDefault(common.ConfigRunnerSubscriberForwardTriesKey, 5)
+	viper.SetDefault(common.ConfigRunnerSubscriberForwardDelayKey, time.Second)
+	viper.SetDefault(common.ConfigRunnerSubscriberBatchTriesKey, 5)
+	viper.SetDefault(common.ConfigRunnerSubscriberBatchDelayKey, time.Second)
+	viper.SetDefault(common.ConfigRunnerSchedulerLockBucketKey, "kai-scheduler-lock")
+	viper.SetDefault(common.ConfigRunnerSchedulerLockTTLKey, 24*time.Hour)
+	viper.SetDefault(common.ConfigRunnerHealthEnabledKey, false)
+	viper.SetDefault(common.ConfigRunnerHealthPortKey, 8080)
 	viper.SetDefault(common.ConfigRunnerLoggerLevelKey, "InfoLevel")
 	viper.SetDefault(common.ConfigRunnerLoggerEncodingKey, "json")
 	viper.SetDefault(common.ConfigRunnerLoggerOutputPathsKey, []string{"stdout"})
 	viper.SetDefault(common.ConfigRunnerLoggerErrorOutputPathsKey, []string{"stderr"})
+	viper.SetDefault(common.ConfigRunnerLoggerLevelConfigKey, "logger_level")
+	viper.SetDefault(common.ConfigCcCacheEnabledKey, false)
+	viper.SetDefault(common.ConfigCcCacheTTLKey, time.Minute)
+	viper.SetDefault(common.ConfigCcCacheMaxSizeKey, 1000)
+	viper.SetDefault(common.ConfigCcSecretsKeyEnvKey, "KAI_SECRETS_KEY")
+	viper.SetDefault(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterOtlpGrpc)
+	viper.SetDefault(common.ConfigMeasurementsShutdownTimeoutKey, 5)
+	viper.SetDefault(common.ConfigMeasurementsLogsEnabledKey, false)
+	viper.SetDefault(common.ConfigMeasurementsTracesEnabledKey, false)
 	viper.SetDefault(common.ConfigMinioInternalFolderKey, ".kai")
 	viper.SetDefault(common.ConfigModelFolderNameKey, ".models")
 }
@@ -148,7 +206,7 @@ func getJetStreamConnection(logger logr.Logger, nc *nats.Conn) (nats.JetStreamCo
 	return js, nil
 }
 
-func getLogger() logr.Logger {
+func getLogger() (logr.Logger, *logging.Levels) {
 	var log logr.Logger
 
 	config := zap.NewProductionConfig()
@@ -158,12 +216,25 @@ func getLogger() logr.Logger {
 		logLevel = zap.NewAtomicLevelAt(zap.InfoLevel)
 	}
 
-	config.Level = zap.NewAtomicLevelAt(logLevel.Level())
+	// The levels are filtered by the outermost core, so they can be changed at runtime per logger.
+	levels := logging.NewLevels(logLevel.Level())
+	config.Level = zap.NewAtomicLevelAt(logging.PermissiveLevel)
 	config.OutputPaths = viper.GetStringSlice(common.ConfigRunnerLoggerOutputPathsKey)
 	config.ErrorOutputPaths = viper.GetStringSlice(common.ConfigRunnerLoggerErrorOutputPathsKey)
 	config.Encoding = viper.GetString(common.ConfigRunnerLoggerEncodingKey)
 
-	logger, err := config.Build()
+	var opts []zap.Option
+
+	// Logs go through the global logger provider, registered by the measurements when they start.
+	if viper.GetBool(common.ConfigMeasurementsLogsEnabledKey) {
+		opts = append(opts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, otelzap.NewCore(_otelLoggerName, otelzap.WithLoggerProvider(global.GetLoggerProvider())))
+		}))
+	}
+
+	opts = append(opts, zap.WrapCore(levels.WrapCore))
+
+	logger, err := config.Build(opts...)
 	if err != nil {
 		panic("The logger could not be initialized")
 	}
@@ -174,13 +245,17 @@ func getLogger() logr.Logger {
 
 	log.WithName("[RUNNER CONFIG]").V(1).Info(fmt.Sprintf("Logger initialized with level %s", logLevel.String()))
 
-	return log
+	return log, levels
 }
 
 func (rn Runner) TriggerRunner() *trigger.Runner {
-	return trigger.NewTriggerRunner(rn.logger, rn.nats, rn.jetstream)
+	return trigger.NewTriggerRunner(rn.logger, rn.nats, rn.jetstream).WithHealthChecker(rn.health)
 }
 
 func (rn Runner) TaskRunner() *task.Runner {
-	return task.NewTaskRunner(rn.logger, rn.nats, rn.jetstream)
+	return task.NewTaskRunner(rn.logger, rn.nats, rn.jetstream).WithHealthChecker(rn.health)
+}
+
+func (rn Runner) ExitRunner() *exit.Runner {
+	return exit.NewExitRunner(rn.logger, rn.nats, rn.jetstream).WithHealthChecker(rn.health)
 }
@@ -5,14 +5,19 @@ package runner_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
 	"github.com/konstellation-io/kai-gosdk/mocks"
 	"github.com/konstellation-io/kai-gosdk/runner"
+	"github.com/konstellation-io/kai-gosdk/runner/task"
+	"github.com/konstellation-io/kai-gosdk/runner/trigger"
+	"github.com/konstellation-io/kai-gosdk/sdk"
 )
 
 type SdkRunnerTestSuite struct {
@@ -60,6 +65,69 @@ func (s *SdkRunnerTestSuite) TestNewTriggerRunner_WithoutRunner_ExpectPanic() {
 	}, "Undefined runner function")
 }
 
+func (s *SdkRunnerTestSuite) TestNewTriggerRunner_WithScheduledRunner_ExpectOK() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	// When
+	triggerRunner := runner.NewTestRunner(nil, &s.js).
+		TriggerRunner().
+		WithScheduledRunner(trigger.Schedule{Cron: "*/5 * * * *", Jitter: time.Second},
+			func(_ *trigger.Runner, _ sdk.KaiSDK) {})
+
+	// Then
+	s.NotNil(triggerRunner)
+}
+
+func (s *SdkRunnerTestSuite) TestNewTriggerRunner_WithInvalidSchedule_ExpectPanic() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	triggerRunner := runner.NewTestRunner(nil, &s.js).TriggerRunner()
+
+	// Then
+	s.Panics(func() {
+		// When
+		triggerRunner.WithScheduledRunner(trigger.Schedule{Cron: "not a cron"},
+			func(_ *trigger.Runner, _ sdk.KaiSDK) {})
+	})
+	s.Panics(func() {
+		// When
+		triggerRunner.WithScheduledRunner(trigger.Schedule{}, func(_ *trigger.Runner, _ sdk.KaiSDK) {})
+	})
+}
+
+func (s *SdkRunnerTestSuite) TestNewRunner_WithPrometheusExporterAndHealthDisabled_ExpectPanic() {
+	// Given
+	viper.Set(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterPrometheus)
+	viper.Set(common.ConfigRunnerHealthEnabledKey, false)
+
+	defer viper.Set(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterOtlpGrpc)
+
+	// Then
+	s.PanicsWithValue("the prometheus measurements exporter requires runner.health.enabled to be true", func() {
+		// When
+		runner.NewTestRunner(nil, &s.js)
+	})
+}
+
+func (s *SdkRunnerTestSuite) TestNewRunner_WithPrometheusExporterAndHealthEnabled_ExpectOK() {
+	// Given
+	viper.Set(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterPrometheus)
+	viper.Set(common.ConfigRunnerHealthEnabledKey, true)
+
+	defer viper.Set(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterOtlpGrpc)
+	defer viper.Set(common.ConfigRunnerHealthEnabledKey, false)
+
+	// When
+	rn := runner.NewTestRunner(nil, &s.js)
+
+	// Then
+	s.NotNil(rn)
+}
+
 func (s *SdkRunnerTestSuite) TestNewTaskRunnerInitialization_ExpectOK() {
 	// Given
 	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
@@ -85,6 +153,120 @@ func (s *SdkRunnerTestSuite) TestNewTaskRunner_WithoutDefaultHandler_ExpectPanic
 	}, "Undefined default handler")
 }
 
+func (s *SdkRunnerTestSuite) TestNewTaskRunner_WithOrderedProcessing_ExpectOK() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	// When
+	taskRunner := runner.NewTestRunner(nil, &s.js).
+		TaskRunner().
+		WithOrderedProcessing(func(_ sdk.KaiSDK, payload *anypb.Any) string {
+			return payload.GetTypeUrl()
+		}, 4)
+
+	// Then
+	s.NotNil(taskRunner)
+}
+
+func (s *SdkRunnerTestSuite) TestNewTaskRunner_WithOrderedProcessingWithoutWorkers_ExpectPanic() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	taskRunner := runner.NewTestRunner(nil, &s.js).TaskRunner()
+
+	// Then
+	s.Panicsf(func() {
+		// When
+		taskRunner.WithOrderedProcessing(func(_ sdk.KaiSDK, _ *anypb.Any) string { return "" }, 0)
+	}, "Invalid number of ordered processing workers")
+}
+
+func (s *SdkRunnerTestSuite) TestNewTaskRunner_WithOrderedProcessingWithoutKeyFunc_ExpectPanic() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	taskRunner := runner.NewTestRunner(nil, &s.js).TaskRunner()
+
+	// Then
+	s.Panicsf(func() {
+		// When
+		taskRunner.WithOrderedProcessing(nil, 4)
+	}, "Undefined partition key function")
+}
+
+func (s *SdkRunnerTestSuite) TestNewTaskRunner_WithBatchHandler_ExpectOK() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	// When
+	taskRunner := runner.NewTestRunner(nil, &s.js).
+		TaskRunner().
+		WithBatchHandler(10, func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+			return make([]error, len(batch))
+		})
+
+	// Then
+	s.NotNil(taskRunner)
+}
+
+func (s *SdkRunnerTestSuite) TestNewTaskRunner_WithInvalidBatchSize_ExpectPanic() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	taskRunner := runner.NewTestRunner(nil, &s.js).TaskRunner()
+
+	// Then
+	s.Panicsf(func() {
+		// When
+		taskRunner.WithBatchHandler(0, nil)
+	}, "Invalid batch size")
+}
+
+func (s *SdkRunnerTestSuite) TestNewExitRunnerInitialization_ExpectOK() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	// When
+	exitRunner := runner.NewTestRunner(nil, &s.js).ExitRunner()
+	// Then
+	s.NotNil(exitRunner)
+}
+
+func (s *SdkRunnerTestSuite) TestNewExitRunner_WithoutDefaultHandler_ExpectPanic() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	// Then
+	s.Panicsf(func() {
+		// When
+		runner.NewTestRunner(nil, &s.js).
+			ExitRunner().
+			Run()
+	}, "Undefined default handler")
+}
+
+func (s *SdkRunnerTestSuite) TestNewExitRunner_NotExitProcess_ExpectPanic() {
+	// Given
+	s.js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	// Then
+	s.Panicsf(func() {
+		// When
+		runner.NewTestRunner(nil, &s.js).
+			ExitRunner().
+			WithHandler(func(_ sdk.KaiSDK, _ *anypb.Any) error { return nil }).
+			Run()
+	}, "Invalid process type")
+}
+
 func (s *SdkRunnerTestSuite) TestNewRunner_MissingMandatoryKey() {
 	// Given
 	natsURL := viper.GetString(common.ConfigNatsURLKey)
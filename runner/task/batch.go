@@ -0,0 +1,151 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	"github.com/konstellation-io/kai-gosdk/internal/tracing"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	runnerCommon "github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+var ErrInvalidBatchResults = errors.New("the batch handler must return one result per item")
+
+// BatchItem is a message of a batch along with the sdk bound to its request.
+type BatchItem struct {
+	SDK     sdk.KaiSDK
+	Payload *anypb.Any
+}
+
+// BatchHandler processes a whole batch of messages and returns one result per item, in the same order.
+// Items with a nil result are acknowledged, the rest are negatively acknowledged to be redelivered, up to
+// runner.subscriber.batch_tries deliveries before an error response is published for them.
+type BatchHandler func(sdk sdk.KaiSDK, batch []BatchItem) []error
+
+func (tr *Runner) fetchMessages(subscription *nats.Subscription, done <-chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	maxWait := viper.GetDuration(common.ConfigRunnerSubscriberFetchMaxWaitKey)
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		msgs, err := subscription.Fetch(tr.batchSize, nats.MaxWait(maxWait))
+
+		switch {
+		case errors.Is(err, nats.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+			continue
+		case errors.Is(err, nats.ErrBadSubscription), errors.Is(err, nats.ErrConnectionClosed):
+			tr.getLoggerWithName().Info(fmt.Sprintf("Stopped fetching messages from subject %s", subscription.Subject))
+			return
+		case err != nil:
+			tr.getLoggerWithName().Error(err, fmt.Sprintf("Error fetching messages from subject %s", subscription.Subject))
+			time.Sleep(maxWait)
+
+			continue
+		}
+
+		tr.processBatch(msgs)
+	}
+}
+
+func (tr *Runner) processBatch(msgs []*nats.Msg) {
+	tr.getLoggerWithName().Info(fmt.Sprintf("New batch received with %d messages", len(msgs)))
+
+	items := make([]BatchItem, 0, len(msgs))
+	batchMsgs := make([]*nats.Msg, 0, len(msgs))
+	requestMsgs := make([]*kai.KaiNatsMessage, 0, len(msgs))
+	attrs := make([]metric.MeasurementOption, 0, len(msgs))
+
+	for _, msg := range msgs {
+		requestMsg, ok := tr.processor.ParseMessage(msg)
+		if !ok {
+			continue
+		}
+
+		items = append(items, BatchItem{
+			SDK:     sdk.ShallowCopyWithMsg(&tr.sdk, msg, requestMsg),
+			Payload: requestMsg.GetPayload(),
+		})
+		batchMsgs = append(batchMsgs, msg)
+		requestMsgs = append(requestMsgs, requestMsg)
+		attrs = append(attrs, metrics.Attributes(tr.sdk.Metadata, msg.Subject, requestMsg.GetFromNode()))
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	defer func() {
+		for _, item := range items {
+			tracing.EndSpan(item.SDK.GetContext())
+		}
+	}()
+
+	start := time.Now()
+	results := tr.batchHandler(tr.sdk, items)
+	executionTime := time.Since(start).Milliseconds()
+
+	tr.sdk.Logger.V(1).Info(fmt.Sprintf("%s batch execution time: %d ms", tr.sdk.Metadata.GetProcess(), executionTime))
+	tr.metrics.ProcessingTime.Record(context.Background(), executionTime,
+		metrics.Attributes(tr.sdk.Metadata, batchMsgs[0].Subject, ""))
+
+	if len(results) != len(items) {
+		tr.getLoggerWithName().Error(ErrInvalidBatchResults,
+			fmt.Sprintf("Expected %d results but got %d, the whole batch will be redelivered", len(items), len(results)))
+
+		for i, msg := range batchMsgs {
+			tr.retryBatchItem(msg, requestMsgs[i], ErrInvalidBatchResults, attrs[i])
+		}
+
+		return
+	}
+
+	for i, msg := range batchMsgs {
+		if results[i] != nil {
+			tr.getLoggerWithName().Error(results[i], fmt.Sprintf("Error in node %q executing batch handler for request id %q",
+				tr.sdk.Metadata.GetProcess(), items[i].SDK.GetRequestID()))
+			tr.retryBatchItem(msg, requestMsgs[i], results[i], attrs[i])
+
+			continue
+		}
+
+		tr.metrics.MessagesProcessed.Add(context.Background(), 1, attrs[i])
+		tr.processor.AckMessage(msg, attrs[i])
+	}
+}
+
+// retryBatchItem naks a failed item to be redelivered after a growing delay, until it has been delivered
+// runner.subscriber.batch_tries times and an error response is published instead.
+func (tr *Runner) retryBatchItem(msg *nats.Msg, requestMsg *kai.KaiNatsMessage, err error,
+	attrs metric.MeasurementOption,
+) {
+	numDelivered := runnerCommon.NumDelivered(msg)
+
+	if numDelivered >= viper.GetUint64(common.ConfigRunnerSubscriberBatchTriesKey) {
+		errMsg := fmt.Sprintf("Error in node %q executing batch handler for node %q after %d deliveries: %s",
+			tr.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), numDelivered, err)
+		tr.processor.ProcessError(msg, errMsg, requestMsg)
+
+		return
+	}
+
+	tr.metrics.MessagesFailed.Add(context.Background(), 1, attrs)
+	tr.processor.NakMessage(msg, runnerCommon.RedeliveryDelay(
+		viper.GetDuration(common.ConfigRunnerSubscriberBatchDelayKey), numDelivered), attrs)
+}
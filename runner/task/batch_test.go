@@ -0,0 +1,297 @@
+//go:build unit
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/runner/task"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+const _batchSubject = "test-stream.batch"
+
+var errBatchItem = errors.New("batch item error")
+
+type BatchTestSuite struct {
+	suite.Suite
+	kaiSDK    sdk.KaiSDK
+	jetstream *mocks.JetStreamContextMock
+	reader    *sdkMetric.ManualReader
+	acked     []*nats.Msg
+	naked     []*nats.Msg
+	nakDelays []time.Duration
+	nakErr    error
+}
+
+func TestBatchTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
+
+func (s *BatchTestSuite) SetupTest() {
+	viper.Reset()
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("../../testdata")
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("fatal error initializing configuration: %w", err))
+	}
+
+	s.jetstream = mocks.NewJetStreamContextMock(s.T())
+	s.jetstream.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	s.jetstream.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	s.kaiSDK = sdk.NewKaiSDK(testr.New(s.T()), nil, s.jetstream)
+	s.reader = sdkMetric.NewManualReader()
+	s.acked = nil
+	s.naked = nil
+	s.nakDelays = nil
+	s.nakErr = nil
+
+	viper.Set(common.ConfigRunnerSubscriberBatchTriesKey, 3)
+	viper.Set(common.ConfigRunnerSubscriberBatchDelayKey, time.Second)
+}
+
+func (s *BatchTestSuite) newRunner(handler task.BatchHandler) *task.Runner {
+	meter := sdkMetric.NewMeterProvider(sdkMetric.WithReader(s.reader)).Meter("test")
+
+	return task.NewTestBatchTaskRunner(s.kaiSDK, s.jetstream, meter, handler,
+		func(msg *nats.Msg) error {
+			s.acked = append(s.acked, msg)
+			return nil
+		},
+		func(msg *nats.Msg, delay time.Duration) error {
+			s.naked = append(s.naked, msg)
+			s.nakDelays = append(s.nakDelays, delay)
+			return s.nakErr
+		})
+}
+
+func (s *BatchTestSuite) newMsg(requestID string) *nats.Msg {
+	payload, err := anypb.New(wrapperspb.String(requestID))
+	s.Require().NoError(err)
+
+	data, err := proto.Marshal(&kai.KaiNatsMessage{
+		RequestId:   requestID,
+		Payload:     payload,
+		FromNode:    "parent-node",
+		MessageType: kai.MessageType_OK,
+	})
+	s.Require().NoError(err)
+
+	return &nats.Msg{Subject: _batchSubject, Data: data}
+}
+
+// newDeliveredMsg returns a stream message delivered the given times, as set in its ack subject.
+func (s *BatchTestSuite) newDeliveredMsg(requestID string, numDelivered int) *nats.Msg {
+	msg := s.newMsg(requestID)
+	msg.Sub = &nats.Subscription{}
+	msg.Reply = fmt.Sprintf("$JS.ACK.test-stream.test-consumer.%d.10.10.1700000000000000000.0", numDelivered)
+
+	return msg
+}
+
+func (s *BatchTestSuite) counter(name string) int64 {
+	var collected metricdata.ResourceMetrics
+
+	s.Require().NoError(s.reader.Collect(context.Background(), &collected))
+
+	var total int64
+
+	for _, scope := range collected.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == name {
+				for _, point := range sum.DataPoints {
+					total += point.Value
+				}
+			}
+		}
+	}
+
+	return total
+}
+
+func (s *BatchTestSuite) TestProcessBatch_AllItemsSucceed_ExpectAllAcked() {
+	// Given
+	msgs := []*nats.Msg{s.newMsg("1"), s.newMsg("2"), s.newMsg("3")}
+
+	var requestIDs []string
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		for _, item := range batch {
+			requestIDs = append(requestIDs, item.SDK.GetRequestID())
+		}
+
+		return make([]error, len(batch))
+	})
+
+	// When
+	tr.ProcessBatch(msgs)
+
+	// Then
+	s.Equal([]string{"1", "2", "3"}, requestIDs)
+	s.Equal(msgs, s.acked)
+	s.Empty(s.naked)
+	s.Equal(int64(3), s.counter("runner-messages-processed"))
+}
+
+func (s *BatchTestSuite) TestProcessBatch_ItemFails_ExpectOnlyFailedItemNaked() {
+	// Given
+	msgs := []*nats.Msg{s.newMsg("1"), s.newMsg("2"), s.newMsg("3")}
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		return []error{nil, errBatchItem, nil}
+	})
+
+	// When
+	tr.ProcessBatch(msgs)
+
+	// Then
+	s.Equal([]*nats.Msg{msgs[0], msgs[2]}, s.acked)
+	s.Equal([]*nats.Msg{msgs[1]}, s.naked)
+	s.Equal([]time.Duration{time.Second}, s.nakDelays)
+	s.Equal(int64(2), s.counter("runner-messages-processed"))
+	s.Equal(int64(1), s.counter("runner-messages-failed"))
+}
+
+func (s *BatchTestSuite) TestProcessBatch_ItemRedelivered_ExpectNakedWithGrowingDelay() {
+	// Given
+	msgs := []*nats.Msg{s.newDeliveredMsg("1", 2)}
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		return []error{errBatchItem}
+	})
+
+	// When
+	tr.ProcessBatch(msgs)
+
+	// Then
+	s.Equal(msgs, s.naked)
+	s.Equal([]time.Duration{2 * time.Second}, s.nakDelays)
+	s.Empty(s.acked)
+}
+
+func (s *BatchTestSuite) TestProcessBatch_ItemTriesExhausted_ExpectAckedWithErrorResponse() {
+	// Given
+	msgs := []*nats.Msg{s.newDeliveredMsg("1", 3), s.newDeliveredMsg("2", 1)}
+
+	// The error response is not published, as the stream info is not available.
+	s.jetstream.On("StreamInfo", mock.AnythingOfType("string")).Return(nil, nats.ErrStreamNotFound)
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		return []error{errBatchItem, errBatchItem}
+	})
+
+	// When
+	tr.ProcessBatch(msgs)
+
+	// Then
+	s.Equal([]*nats.Msg{msgs[0]}, s.acked)
+	s.Equal([]*nats.Msg{msgs[1]}, s.naked)
+	s.jetstream.AssertNumberOfCalls(s.T(), "StreamInfo", 1)
+	s.Equal(int64(2), s.counter("runner-messages-failed"))
+}
+
+func (s *BatchTestSuite) TestProcessBatch_NakFails_ExpectAckFailureRecorded() {
+	// Given
+	msgs := []*nats.Msg{s.newMsg("1"), s.newMsg("2")}
+	s.nakErr = nats.ErrMsgNotBound
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		return []error{errBatchItem, nil}
+	})
+
+	// When
+	tr.ProcessBatch(msgs)
+
+	// Then
+	s.Equal([]*nats.Msg{msgs[0]}, s.naked)
+	s.Equal([]*nats.Msg{msgs[1]}, s.acked)
+	s.Equal(int64(1), s.counter("runner-ack-failures"))
+}
+
+func (s *BatchTestSuite) TestProcessBatch_ResultsLengthMismatch_ExpectWholeBatchNaked() {
+	// Given
+	msgs := []*nats.Msg{s.newMsg("1"), s.newMsg("2"), s.newMsg("3")}
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		return make([]error, len(batch)-1)
+	})
+
+	// When
+	tr.ProcessBatch(msgs)
+
+	// Then
+	s.Empty(s.acked)
+	s.Equal(msgs, s.naked)
+	s.Equal(int64(3), s.counter("runner-messages-failed"))
+	s.Zero(s.counter("runner-messages-processed"))
+}
+
+func (s *BatchTestSuite) TestProcessBatch_InvalidMessage_ExpectAckedAndLeftOutOfBatch() {
+	// Given
+	invalidMsg := &nats.Msg{Subject: _batchSubject, Data: []byte("not a protobuf")}
+	msgs := []*nats.Msg{s.newMsg("1"), invalidMsg, s.newMsg("3")}
+
+	// The error message is not published, as the stream info is not available.
+	s.jetstream.On("StreamInfo", mock.AnythingOfType("string")).Return(nil, nats.ErrStreamNotFound)
+
+	var requestIDs []string
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		for _, item := range batch {
+			requestIDs = append(requestIDs, item.SDK.GetRequestID())
+		}
+
+		return make([]error, len(batch))
+	})
+
+	// When
+	tr.ProcessBatch(msgs)
+
+	// Then
+	s.Equal([]string{"1", "3"}, requestIDs)
+	s.Equal([]*nats.Msg{invalidMsg, msgs[0], msgs[2]}, s.acked)
+	s.Empty(s.naked)
+	s.Equal(int64(1), s.counter("runner-messages-failed"))
+}
+
+func (s *BatchTestSuite) TestProcessBatch_OnlyInvalidMessages_ExpectHandlerNotCalled() {
+	// Given
+	invalidMsg := &nats.Msg{Subject: _batchSubject, Data: []byte("not a protobuf")}
+	s.jetstream.On("StreamInfo", mock.AnythingOfType("string")).Return(nil, nats.ErrStreamNotFound)
+
+	called := false
+
+	tr := s.newRunner(func(_ sdk.KaiSDK, batch []task.BatchItem) []error {
+		called = true
+		return make([]error, len(batch))
+	})
+
+	// When
+	tr.ProcessBatch([]*nats.Msg{invalidMsg})
+
+	// Then
+	s.False(called)
+	s.Equal([]*nats.Msg{invalidMsg}, s.acked)
+}
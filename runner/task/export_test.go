@@ -0,0 +1,58 @@
+//go:build unit
+
+package task
+
+import (
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+func NewTestBatchTaskRunner(kaiSDK sdk.KaiSDK, js nats.JetStreamContext, meter metric.Meter, handler BatchHandler,
+	ack common.AckFunc, nak common.NakFunc,
+) *Runner {
+	runnerMetrics, _ := metrics.NewRunner(meter)
+
+	tr := &Runner{
+		sdk:          kaiSDK,
+		jetstream:    js,
+		metrics:      runnerMetrics,
+		publisher:    common.NewPublisher(kaiSDK, nil, js, runnerMetrics),
+		batchHandler: handler,
+		ack:          ack,
+		nak:          nak,
+	}
+	tr.processor = tr.newProcessor()
+
+	return tr
+}
+
+func (tr *Runner) ProcessBatch(msgs []*nats.Msg) {
+	tr.processBatch(msgs)
+}
+
+type Partitioner = partitioner
+
+func NewTestPartitioner(keyFunc PartitionKeyFunc, workers int) *Partitioner {
+	return newPartitioner(keyFunc, workers)
+}
+
+func (p *partitioner) Start(process func(msg *nats.Msg, requestMsg *kai.KaiNatsMessage)) {
+	p.start(process)
+}
+
+func (p *partitioner) Dispatch(key string, msg *nats.Msg, requestMsg *kai.KaiNatsMessage) error {
+	return p.dispatch(key, msg, requestMsg)
+}
+
+func (p *partitioner) Stop() {
+	p.stop()
+}
+
+func (p *partitioner) GetPartition(key string) int {
+	return p.getPartition(key)
+}
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"fmt"
+
 	"github.com/konstellation-io/kai-gosdk/runner/common"
 	"github.com/konstellation-io/kai-gosdk/sdk"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -18,6 +20,8 @@ func composeInitializer(initializer common.Initializer) common.Initializer {
 	return func(kaiSDK sdk.KaiSDK) {
 		kaiSDK.Logger.WithName(_initializerLoggerName).V(1).Info("Initializing TaskRunner...")
 		common.InitializeProcessConfiguration(kaiSDK)
+		common.ValidateProcessConfiguration(kaiSDK)
+		common.StartMeasurements(kaiSDK)
 
 		if initializer != nil {
 			kaiSDK.Logger.WithName(_initializerLoggerName).V(3).Info("Executing user initializer...")
@@ -55,6 +59,19 @@ func composeHandler(handler Handler) Handler {
 	}
 }
 
+func composeBatchHandler(handler BatchHandler) BatchHandler {
+	return func(kaiSDK sdk.KaiSDK, batch []BatchItem) []error {
+		kaiSDK.Logger.WithName(_handlerLoggerName).V(1).Info(fmt.Sprintf("Handling batch of %d messages...", len(batch)))
+
+		if handler != nil {
+			kaiSDK.Logger.WithName(_handlerLoggerName).V(3).Info("Executing user batch handler...")
+			return handler(kaiSDK, batch)
+		}
+
+		return make([]error, len(batch))
+	}
+}
+
 func composePostprocessor(postprocessor Postprocessor) Postprocessor {
 	return func(kaiSDK sdk.KaiSDK, response *anypb.Any) error {
 		kaiSDK.Logger.WithName(_postprocessorLoggerName).V(1).Info("Postprocessing TaskRunner...")
@@ -77,5 +94,7 @@ func composeFinalizer(finalizer common.Finalizer) common.Finalizer {
 			finalizer(kaiSDK)
 			kaiSDK.Logger.WithName(_finalizerLoggerName).V(3).Info("User finalizer executed")
 		}
+
+		common.ShutdownMeasurements(kaiSDK)
 	}
 }
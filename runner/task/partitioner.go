@@ -0,0 +1,100 @@
+package task
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+const _partitionWorkerBufferSize = 64
+
+// PartitionKeyFunc returns the key used to process messages in order, usually an entity id read from the payload.
+// Messages with the same key are processed serially in the order they are received,
+// while messages with different keys are processed in parallel.
+type PartitionKeyFunc func(sdk sdk.KaiSDK, payload *anypb.Any) string
+
+type partitionedMessage struct {
+	msg        *nats.Msg
+	requestMsg *kai.KaiNatsMessage
+}
+
+type partitioner struct {
+	keyFunc    PartitionKeyFunc
+	partitions []chan partitionedMessage
+	next       atomic.Uint32
+	mu         sync.RWMutex
+	closed     bool
+	wg         sync.WaitGroup
+}
+
+func newPartitioner(keyFunc PartitionKeyFunc, workers int) *partitioner {
+	partitions := make([]chan partitionedMessage, workers)
+	for i := range partitions {
+		partitions[i] = make(chan partitionedMessage, _partitionWorkerBufferSize)
+	}
+
+	return &partitioner{
+		keyFunc:    keyFunc,
+		partitions: partitions,
+	}
+}
+
+func (p *partitioner) start(process func(msg *nats.Msg, requestMsg *kai.KaiNatsMessage)) {
+	for _, partition := range p.partitions {
+		p.wg.Add(1)
+
+		go func(messages <-chan partitionedMessage) {
+			defer p.wg.Done()
+
+			for m := range messages {
+				process(m.msg, m.requestMsg)
+			}
+		}(partition)
+	}
+}
+
+func (p *partitioner) dispatch(key string, msg *nats.Msg, requestMsg *kai.KaiNatsMessage) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return fmt.Errorf("message with request id %q received after the partitions were closed",
+			requestMsg.GetRequestId())
+	}
+
+	p.partitions[p.getPartition(key)] <- partitionedMessage{msg: msg, requestMsg: requestMsg}
+
+	return nil
+}
+
+// stop waits until every partition has processed its pending messages.
+func (p *partitioner) stop() {
+	p.mu.Lock()
+	p.closed = true
+
+	for _, partition := range p.partitions {
+		close(partition)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *partitioner) getPartition(key string) int {
+	// Messages without key have no ordering requirements, they are spread across partitions.
+	if key == "" {
+		return int(p.next.Add(1) % uint32(len(p.partitions))) //nolint:gosec // The number of partitions is small
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+
+	return int(hash.Sum32() % uint32(len(p.partitions))) //nolint:gosec // The number of partitions is small
+}
@@ -0,0 +1,160 @@
+//go:build unit
+
+package task_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/runner/task"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+const _processTimeout = 5 * time.Second
+
+type PartitionerTestSuite struct {
+	suite.Suite
+}
+
+func TestPartitionerTestSuite(t *testing.T) {
+	suite.Run(t, new(PartitionerTestSuite))
+}
+
+func (s *PartitionerTestSuite) TestDispatch_SameKey_ExpectProcessedInOrder() {
+	// Given
+	p := task.NewTestPartitioner(keyFunc, 4)
+
+	var (
+		mu        sync.Mutex
+		processed []string
+	)
+
+	p.Start(func(_ *nats.Msg, requestMsg *kai.KaiNatsMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		processed = append(processed, requestMsg.GetRequestId())
+	})
+
+	expected := make([]string, 0, 200)
+
+	// When
+	for i := 0; i < 200; i++ {
+		requestID := fmt.Sprintf("request-%d", i)
+		expected = append(expected, requestID)
+
+		s.Require().NoError(p.Dispatch("entity-1", &nats.Msg{}, &kai.KaiNatsMessage{RequestId: requestID}))
+	}
+
+	p.Stop()
+
+	// Then
+	s.Equal(expected, processed)
+}
+
+func (s *PartitionerTestSuite) TestDispatch_DifferentKeys_ExpectProcessedInParallel() {
+	// Given
+	p := task.NewTestPartitioner(keyFunc, 4)
+	firstKey, secondKey := keysInDifferentPartitions(p)
+
+	started := make(chan string, 2)
+	release := make(chan struct{})
+
+	p.Start(func(_ *nats.Msg, requestMsg *kai.KaiNatsMessage) {
+		started <- requestMsg.GetRequestId()
+		<-release
+	})
+
+	// When
+	s.Require().NoError(p.Dispatch(firstKey, &nats.Msg{}, &kai.KaiNatsMessage{RequestId: firstKey}))
+	s.Require().NoError(p.Dispatch(secondKey, &nats.Msg{}, &kai.KaiNatsMessage{RequestId: secondKey}))
+
+	// Then, both messages are in flight at the same time.
+	inFlight := make([]string, 0, 2)
+
+	for len(inFlight) < 2 {
+		select {
+		case requestID := <-started:
+			inFlight = append(inFlight, requestID)
+		case <-time.After(_processTimeout):
+			s.FailNow("messages with different keys were not processed in parallel")
+		}
+	}
+
+	close(release)
+	p.Stop()
+
+	s.ElementsMatch([]string{firstKey, secondKey}, inFlight)
+}
+
+func (s *PartitionerTestSuite) TestStop_WithPendingMessages_ExpectDrained() {
+	// Given
+	p := task.NewTestPartitioner(keyFunc, 2)
+
+	var processed atomic.Int32
+
+	p.Start(func(_ *nats.Msg, _ *kai.KaiNatsMessage) {
+		time.Sleep(time.Millisecond)
+		processed.Add(1)
+	})
+
+	for i := 0; i < 50; i++ {
+		s.Require().NoError(p.Dispatch(fmt.Sprintf("entity-%d", i%3), &nats.Msg{}, &kai.KaiNatsMessage{}))
+	}
+
+	// When
+	p.Stop()
+
+	// Then
+	s.Equal(int32(50), processed.Load())
+}
+
+func (s *PartitionerTestSuite) TestDispatch_AfterStop_ExpectError() {
+	// Given
+	p := task.NewTestPartitioner(keyFunc, 2)
+	p.Start(func(_ *nats.Msg, _ *kai.KaiNatsMessage) {})
+	p.Stop()
+
+	// When
+	err := p.Dispatch("entity-1", &nats.Msg{}, &kai.KaiNatsMessage{RequestId: "123"})
+
+	// Then
+	s.Error(err)
+}
+
+func (s *PartitionerTestSuite) TestGetPartition_WithoutKey_ExpectSpreadAcrossPartitions() {
+	// Given
+	p := task.NewTestPartitioner(keyFunc, 4)
+
+	// When
+	partitions := make(map[int]bool)
+	for i := 0; i < 4; i++ {
+		partitions[p.GetPartition("")] = true
+	}
+
+	// Then
+	s.Len(partitions, 4)
+}
+
+func keyFunc(_ sdk.KaiSDK, payload *anypb.Any) string {
+	return payload.GetTypeUrl()
+}
+
+func keysInDifferentPartitions(p *task.Partitioner) (string, string) {
+	firstKey := "entity-0"
+
+	for i := 1; ; i++ {
+		key := fmt.Sprintf("entity-%d", i)
+		if p.GetPartition(key) != p.GetPartition(firstKey) {
+			return firstKey, key
+		}
+	}
+}
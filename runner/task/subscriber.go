@@ -1,24 +1,20 @@
 package task
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
-	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/nats-io/nats.go"
 	"github.com/spf13/viper"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/metric"
-	"google.golang.org/protobuf/proto"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
-	"github.com/konstellation-io/kai-gosdk/internal/errors"
-	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	runnerCommon "github.com/konstellation-io/kai-gosdk/runner/common"
 	"github.com/konstellation-io/kai-gosdk/sdk"
 )
 
@@ -38,16 +34,25 @@ func (tr *Runner) startSubscriber() {
 
 	var err error
 
-	tr.messagesMetric, err = tr.sdk.Measurements.GetMetricsClient().Int64Histogram(
-		"runner-process-message-metric",
-		metric.WithDescription("How long it takes to process a message and times called."),
-		metric.WithUnit("ms"),
-	)
+	tr.metrics, err = metrics.NewRunner(tr.sdk.Measurements.GetMetricsClient())
 	if err != nil {
-		tr.getLoggerWithName().Error(err, "Error initializing metric")
+		tr.getLoggerWithName().Error(err, "Error initializing metrics")
 		os.Exit(1)
 	}
 
+	tr.publisher = runnerCommon.NewPublisher(tr.sdk, tr.nats, tr.jetstream, tr.metrics)
+	tr.processor = tr.newProcessor()
+
+	callback := tr.processor.ProcessMessage
+
+	if tr.partitioner != nil {
+		tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Ordered processing enabled with %d partitions",
+			len(tr.partitioner.partitions)))
+		tr.partitioner.start(tr.processor.ProcessRequest)
+
+		callback = tr.dispatchMessage
+	}
+
 	subscriptions := make([]*nats.Subscription, 0, len(inputSubjects))
 
 	for _, subject := range inputSubjects {
@@ -56,15 +61,7 @@ func (tr *Runner) startSubscriber() {
 
 		tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Subscribing to subject %s with queue group %s", subject, consumerName))
 
-		s, err := tr.jetstream.QueueSubscribe(
-			subject,
-			consumerName,
-			tr.processMessage,
-			nats.DeliverNew(),
-			nats.Durable(consumerName),
-			nats.ManualAck(),
-			nats.AckWait(viper.GetDuration(common.ConfigRunnerSubscriberAckWaitTimeKey)),
-		)
+		s, err := tr.subscribe(subject, consumerName, callback)
 		if err != nil {
 			tr.getLoggerWithName().Error(err, fmt.Sprintf("Error subscribing to subject %s", subject))
 			os.Exit(1)
@@ -75,7 +72,20 @@ func (tr *Runner) startSubscriber() {
 		tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Listening to subject %s with queue group %s", subject, consumerName))
 	}
 
+	fetchersDone := make(chan struct{})
+
+	var fetchers sync.WaitGroup
+
+	if tr.batchHandler != nil {
+		for _, s := range subscriptions {
+			fetchers.Add(1)
+
+			go tr.fetchMessages(s, fetchersDone, &fetchers)
+		}
+	}
+
 	tr.getLoggerWithName().V(1).Info("Subscribed to all subjects successfully")
+	tr.health.SetSubscribed(true)
 
 	// Handle sigterm and await termChan signal
 	termChan := make(chan os.Signal, 1)
@@ -84,6 +94,10 @@ func (tr *Runner) startSubscriber() {
 
 	// Handle shutdown
 	tr.getLoggerWithName().Info("Shutdown signal received")
+	tr.health.SetSubscribed(false)
+
+	close(fetchersDone)
+	fetchers.Wait()
 
 	tr.getLoggerWithName().V(1).Info("Unsubscribing from all subjects")
 
@@ -98,235 +112,71 @@ func (tr *Runner) startSubscriber() {
 	}
 
 	tr.getLoggerWithName().Info("Unsubscribed from all subjects")
-}
 
-func (tr *Runner) processMessage(msg *nats.Msg) {
-	requestMsg, err := tr.newRequestMessage(msg.Data)
-	if err != nil {
-		errMsg := fmt.Sprintf("Error parsing msg.data coming from subject %s because is not a valid protobuf: %s", msg.Subject, err)
-		tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
-
-		return
-	}
-
-	start := time.Now()
-	defer func() {
-		executionTime := time.Since(start).Milliseconds()
-		tr.sdk.Logger.V(1).Info(fmt.Sprintf("%s execution time: %d ms", tr.sdk.Metadata.GetProcess(), executionTime))
-
-		tr.messagesMetric.Record(context.Background(), executionTime,
-			metric.WithAttributeSet(tr.getMetricAttributes(requestMsg.GetRequestId())),
-		)
-	}()
-
-	tr.getLoggerWithName().Info(fmt.Sprintf("New message received with subject %s",
-		msg.Subject))
-
-	handler := tr.getResponseHandler(strings.ToLower(requestMsg.GetFromNode()))
-	if handler == nil {
-		errMsg := fmt.Sprintf("Error missing handler for node %q", requestMsg.GetFromNode())
-		tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
-
-		return
+	if tr.partitioner != nil {
+		tr.getLoggerWithName().V(1).Info("Waiting for the pending messages of every partition")
+		tr.partitioner.stop()
 	}
+}
 
-	// Make a shallow copy of the sdk object to set inside the request msg.
-	hSdk := sdk.ShallowCopyWithRequest(&tr.sdk, requestMsg)
+func (tr *Runner) newProcessor() *runnerCommon.Processor {
+	processor := runnerCommon.NewProcessor(tr.sdk, tr.metrics, tr.publisher, tr.responseHandlers, tr.ack, tr.nak)
 
 	if tr.preprocessor != nil {
-		err := tr.preprocessor(hSdk, requestMsg.GetPayload())
-		if err != nil {
-			errMsg := fmt.Sprintf("Error in node %q executing handler preprocessor for node %q: %s",
-				tr.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
-			tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
-
-			return
-		}
-	}
-
-	err = handler(hSdk, requestMsg.GetPayload())
-	if err != nil {
-		errMsg := fmt.Sprintf("Error in node %q executing handler for node %q: %s",
-			tr.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
-		tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
-
-		return
+		processor.WithPreprocessor(runnerCommon.Handler(tr.preprocessor))
 	}
 
 	if tr.postprocessor != nil {
-		err := tr.postprocessor(hSdk, requestMsg.GetPayload())
-		if err != nil {
-			errMsg := fmt.Sprintf("Error in node %q executing handler postprocessor for node %q: %s",
-				tr.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
-			tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
-
-			return
-		}
+		processor.WithPostprocessor(runnerCommon.Handler(tr.postprocessor))
 	}
 
-	// Tell NATS we don't need to receive the message anymore, and we are done processing it.
-	ackErr := msg.Ack()
-	if ackErr != nil {
-		tr.getLoggerWithName().Error(ackErr, errors.ErrMsgAck)
-	}
+	return processor
 }
 
-func (tr *Runner) processRunnerError(msg *nats.Msg, errMsg, requestID string) {
-	ackErr := msg.Ack()
-	if ackErr != nil {
-		tr.getLoggerWithName().Error(ackErr, errors.ErrMsgAck)
+func (tr *Runner) subscribe(subject, consumerName string, callback nats.MsgHandler) (*nats.Subscription, error) {
+	consumerConfig, err := runnerCommon.GetConsumerConfig(subject)
+	if err != nil {
+		return nil, err
 	}
 
-	tr.getLoggerWithName().V(1).Info(errMsg)
-	tr.publishError(requestID, errMsg)
-}
+	if tr.batchHandler != nil {
+		// Pull consumers cannot share the durable of a push consumer.
+		durableName := consumerConfig.GetDurableName(fmt.Sprintf("%s-pull", consumerName))
 
-func (tr *Runner) newRequestMessage(data []byte) (*kai.KaiNatsMessage, error) {
-	requestMsg := &kai.KaiNatsMessage{}
-
-	var err error
-	if common.IsCompressed(data) {
-		data, err = common.UncompressData(data)
+		opts, err := consumerConfig.GetSubscribeOptions(tr.sdk.Logger, tr.jetstream, durableName, true)
 		if err != nil {
-			tr.getLoggerWithName().Error(err, "Error reading compressed message")
 			return nil, err
 		}
-	}
-
-	err = proto.Unmarshal(data, requestMsg)
-
-	return requestMsg, err
-}
-
-func (tr *Runner) publishError(requestID, errMsg string) {
-	responseMsg := &kai.KaiNatsMessage{
-		RequestId:   requestID,
-		Error:       errMsg,
-		FromNode:    viper.GetString(common.ConfigMetadataProcessIDKey),
-		MessageType: kai.MessageType_ERROR,
-	}
-	tr.publishResponse(responseMsg, "")
-}
-
-func (tr *Runner) publishResponse(responseMsg *kai.KaiNatsMessage, channel string) {
-	outputSubject := tr.getOutputSubject(channel)
-
-	outputMsg, err := proto.Marshal(responseMsg)
-	if err != nil {
-		tr.getLoggerWithName().
-			Error(err, "Error generating output result because handler result is not a serializable Protobuf")
-		return
-	}
-
-	outputMsg, err = tr.prepareOutputMessage(outputMsg)
-	if err != nil {
-		tr.getLoggerWithName().Error(err, "Error preparing output message")
-		return
-	}
-
-	tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Publishing response with subject %s", outputSubject))
-
-	_, err = tr.jetstream.Publish(outputSubject, outputMsg)
-	if err != nil {
-		tr.getLoggerWithName().Error(err, "Error publishing output")
-	}
-}
 
-func (tr *Runner) getOutputSubject(channel string) string {
-	outputSubject := viper.GetString(common.ConfigNatsOutputKey)
-	if channel != "" {
-		return fmt.Sprintf("%s.%s", outputSubject, channel)
-	}
+		tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Using pull consumer %s with batches of %d messages",
+			durableName, tr.batchSize))
 
-	return outputSubject
-}
-
-// prepareOutputMessage will check the length of the message and compress it if necessary.
-// Fails on compressed messages bigger than the threshold.
-func (tr *Runner) prepareOutputMessage(msg []byte) ([]byte, error) {
-	maxSize, err := tr.getMaxMessageSize()
-	if err != nil {
-		return nil, fmt.Errorf("error getting max message size: %w", err)
+		return tr.jetstream.PullSubscribe(subject, durableName, opts...)
 	}
 
-	lenMsg := int64(len(msg))
-	if lenMsg <= maxSize {
-		return msg, nil
-	}
+	durableName := consumerConfig.GetDurableName(consumerName)
 
-	outMsg, err := common.CompressData(msg)
+	opts, err := consumerConfig.GetSubscribeOptions(tr.sdk.Logger, tr.jetstream, durableName, false)
 	if err != nil {
 		return nil, err
 	}
 
-	lenOutMsg := int64(len(outMsg))
-	if lenOutMsg > maxSize {
-		tr.getLoggerWithName().V(1).Info("Compressed message size %s "+
-			"exceeds maximum size allowed %s", sizeInMB(lenOutMsg), sizeInMB(maxSize))
-		return nil, errors.ErrMessageToBig
-	}
-
-	tr.getLoggerWithName().Info(fmt.Sprintf("Message prepared with original size %s "+
-		"and compressed size %s", sizeInMB(lenMsg), sizeInMB(lenOutMsg)))
-
-	return outMsg, nil
+	return tr.jetstream.QueueSubscribe(subject, durableName, callback, opts...)
 }
 
-func (tr *Runner) getResponseHandler(subject string) Handler {
-	if responseHandler, ok := tr.responseHandlers[subject]; ok {
-		return responseHandler
+// dispatchMessage sends the message to the partition of its key, so messages with the same key are processed in order.
+func (tr *Runner) dispatchMessage(msg *nats.Msg) {
+	requestMsg, ok := tr.processor.ParseMessage(msg)
+	if !ok {
+		return
 	}
 
-	// returns the default response handler, or nil if it doesn't exist
-	return tr.responseHandlers["default"]
-}
+	key := tr.partitioner.keyFunc(sdk.ShallowCopyWithRequest(&tr.sdk, requestMsg), requestMsg.GetPayload())
 
-func (tr *Runner) getMaxMessageSize() (int64, error) {
-	streamInfo, err := tr.jetstream.StreamInfo(viper.GetString(common.ConfigNatsStreamKey))
+	err := tr.partitioner.dispatch(key, msg, requestMsg)
 	if err != nil {
-		return 0, fmt.Errorf("error getting stream's max message size: %w", err)
-	}
-
-	streamMaxSize := int64(streamInfo.Config.MaxMsgSize)
-	serverMaxSize := tr.nats.MaxPayload()
-
-	if streamMaxSize == -1 {
-		return serverMaxSize, nil
-	}
+		tr.getLoggerWithName().Error(err, "Error dispatching message")
 
-	if streamMaxSize < serverMaxSize {
-		return streamMaxSize, nil
+		tr.processor.NakMessage(msg, 0, metrics.Attributes(tr.sdk.Metadata, msg.Subject, requestMsg.GetFromNode()))
 	}
-
-	return serverMaxSize, nil
-}
-
-func (tr *Runner) getMetricAttributes(requestID string) attribute.Set {
-	return attribute.NewSet(
-		attribute.KeyValue{
-			Key:   "product",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetProduct()),
-		},
-		attribute.KeyValue{
-			Key:   "version",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetVersion()),
-		},
-		attribute.KeyValue{
-			Key:   "workflow",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetWorkflow()),
-		},
-		attribute.KeyValue{
-			Key:   "process",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetProcess()),
-		},
-		attribute.KeyValue{
-			Key:   "request_id",
-			Value: attribute.StringValue(requestID),
-		},
-	)
-}
-
-func sizeInMB(size int64) string {
-	mbSize := float32(size) / 1024 / 1024
-	return fmt.Sprintf("%.1f MB", mbSize)
 }
@@ -5,9 +5,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/nats-io/nats.go"
-	"go.opentelemetry.io/otel/metric"
 
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
 	"github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/runner/health"
 	"github.com/konstellation-io/kai-gosdk/sdk"
 )
 
@@ -23,12 +24,21 @@ type Runner struct {
 	sdk              sdk.KaiSDK
 	nats             *nats.Conn
 	jetstream        nats.JetStreamContext
-	responseHandlers map[string]Handler
+	health           *health.Checker
+	responseHandlers map[string]common.Handler
 	initializer      common.Initializer
 	preprocessor     Preprocessor
 	postprocessor    Postprocessor
 	finalizer        common.Finalizer
-	messagesMetric   metric.Int64Histogram
+	metrics          *metrics.Runner
+	publisher        *common.Publisher
+	partitioner      *partitioner
+	batchHandler     BatchHandler
+	batchSize        int
+	processor        *common.Processor
+	// ack and nak acknowledge the messages received from the stream.
+	ack common.AckFunc
+	nak common.NakFunc
 }
 
 func NewTaskRunner(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContext) *Runner {
@@ -36,10 +46,18 @@ func NewTaskRunner(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContext)
 		sdk:              sdk.NewKaiSDK(logger.WithName(_taskLoggerName), ns, js),
 		nats:             ns,
 		jetstream:        js,
-		responseHandlers: make(map[string]Handler),
+		responseHandlers: make(map[string]common.Handler),
+		ack:              common.AckMsg,
+		nak:              common.NakMsg,
 	}
 }
 
+// WithHealthChecker sets the checker serving the health endpoints, updated as the runner starts and stops.
+func (tr *Runner) WithHealthChecker(hc *health.Checker) *Runner {
+	tr.health = hc
+	return tr
+}
+
 func (tr *Runner) WithInitializer(initializer common.Initializer) *Runner {
 	tr.initializer = composeInitializer(initializer)
 	return tr
@@ -51,12 +69,12 @@ func (tr *Runner) WithPreprocessor(preprocessor Preprocessor) *Runner {
 }
 
 func (tr *Runner) WithHandler(handler Handler) *Runner {
-	tr.responseHandlers["default"] = composeHandler(handler)
+	tr.responseHandlers["default"] = common.Handler(composeHandler(handler))
 	return tr
 }
 
 func (tr *Runner) WithCustomHandler(subject string, handler Handler) *Runner {
-	tr.responseHandlers[strings.ToLower(subject)] = composeHandler(handler)
+	tr.responseHandlers[strings.ToLower(subject)] = common.Handler(composeHandler(handler))
 	return tr
 }
 
@@ -65,16 +83,51 @@ func (tr *Runner) WithPostprocessor(postprocessor Postprocessor) *Runner {
 	return tr
 }
 
+// WithBatchHandler switches the runner to a pull consumer that fetches up to batchSize messages at a time
+// and processes them all at once with the given handler. Preprocessor, postprocessor and node handlers are not used.
+func (tr *Runner) WithBatchHandler(batchSize int, handler BatchHandler) *Runner {
+	if batchSize <= 0 {
+		panic("Invalid batch size")
+	}
+
+	tr.batchSize = batchSize
+	tr.batchHandler = composeBatchHandler(handler)
+
+	return tr
+}
+
+// WithOrderedProcessing processes serially and in order the messages sharing the same partition key,
+// while messages with different keys are processed in parallel by the given number of workers.
+// Ordering only holds within a replica: the replicas of a queue group receive different messages, so messages
+// with the same key may still be processed concurrently by different replicas.
+func (tr *Runner) WithOrderedProcessing(keyFunc PartitionKeyFunc, workers int) *Runner {
+	if keyFunc == nil {
+		panic("Undefined partition key function")
+	}
+
+	if workers <= 0 {
+		panic("Invalid number of ordered processing workers")
+	}
+
+	tr.partitioner = newPartitioner(keyFunc, workers)
+
+	return tr
+}
+
 func (tr *Runner) WithFinalizer(finalizer common.Finalizer) *Runner {
 	tr.finalizer = composeFinalizer(finalizer)
 	return tr
 }
 
 func (tr *Runner) Run() {
-	if tr.responseHandlers["default"] == nil {
+	if tr.responseHandlers["default"] == nil && tr.batchHandler == nil {
 		panic("Undefined default handler")
 	}
 
+	if tr.batchHandler != nil && tr.partitioner != nil {
+		panic("Ordered processing is not supported along with a batch handler")
+	}
+
 	if tr.initializer == nil {
 		tr.initializer = composeInitializer(nil)
 	}
@@ -83,9 +136,14 @@ func (tr *Runner) Run() {
 		tr.finalizer = composeFinalizer(nil)
 	}
 
+	tr.health.Start()
+
 	tr.initializer(tr.sdk)
+	tr.health.SetInitialized()
 
 	tr.startSubscriber()
 
 	tr.finalizer(tr.sdk)
+
+	tr.health.Shutdown()
 }
@@ -0,0 +1,85 @@
+//go:build unit
+
+package trigger
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+func NewTestTriggerRunner(kaiSDK sdk.KaiSDK, replySubject string,
+	request func(msg *nats.Msg, timeout time.Duration) (*nats.Msg, error),
+) *Runner {
+	runnerMetrics, _ := metrics.NewRunner(noop.NewMeterProvider().Meter("test"))
+
+	tr := &Runner{
+		sdk:          kaiSDK,
+		metrics:      runnerMetrics,
+		replySubject: replySubject,
+		request:      request,
+	}
+	tr.responseHandler = getResponseHandler(&tr.responseChannels)
+
+	return tr
+}
+
+func (tr *Runner) WithAcks(ack func(msg *nats.Msg) error, nak func(msg *nats.Msg, delay time.Duration) error,
+	term func(msg *nats.Msg) error,
+) *Runner {
+	tr.ack = ack
+	tr.nak = nak
+	tr.term = term
+
+	return tr
+}
+
+func (tr *Runner) ProcessMessage(msg *nats.Msg) {
+	tr.processMessage(msg)
+}
+
+func (tr *Runner) WaitForwards() {
+	tr.forwards.Wait()
+}
+
+func (tr *Runner) ForwardMessage(msg *nats.Msg, replySubject, requestID string) error {
+	return tr.forwardMessage(msg, replySubject, requestID)
+}
+
+func (tr *Runner) ProcessReply(msg *nats.Msg) {
+	tr.processReply(msg)
+}
+
+type Scheduler = scheduler
+
+func NewTestScheduler(schedule Schedule, task ScheduledFunc, js nats.JetStreamContext) (*Scheduler, error) {
+	return newScheduler(schedule, task, js)
+}
+
+func (s *scheduler) Next(t time.Time) time.Time {
+	return s.schedule.Next(t)
+}
+
+func (s *scheduler) GetJitter() time.Duration {
+	return s.getJitter()
+}
+
+func (s *scheduler) Run(kaiSDK sdk.KaiSDK) {
+	s.run(nil, kaiSDK)
+}
+
+func (s *scheduler) Fire(kaiSDK sdk.KaiSDK, tick time.Time) {
+	s.fire(nil, kaiSDK, tick)
+}
+
+func (s *scheduler) AcquireLock(kaiSDK sdk.KaiSDK, tick time.Time) (bool, error) {
+	return s.acquireLock(kaiSDK, tick)
+}
+
+func (s *scheduler) Stop() {
+	s.stop()
+}
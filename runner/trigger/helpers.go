@@ -30,6 +30,8 @@ func composeInitializer(initializer common.Initializer) common.Initializer {
 	return func(sdk sdk.KaiSDK) {
 		sdk.Logger.WithName(_initializerLoggerName).V(1).Info("Initializing TriggerRunner...")
 		common.InitializeProcessConfiguration(sdk)
+		common.ValidateProcessConfiguration(sdk)
+		common.StartMeasurements(sdk)
 
 		if initializer != nil {
 			sdk.Logger.WithName(_initializerLoggerName).V(3).Info("Executing user initializer...")
@@ -110,6 +112,8 @@ func composeFinalizer(userFinalizer common.Finalizer) common.Finalizer {
 			kaiSDK.Logger.WithName(_finalizerLoggerName).V(3).Info("User finalizer executed")
 		}
 
+		common.ShutdownMeasurements(kaiSDK)
+
 		kaiSDK.Logger.WithName(_finalizerLoggerName).V(1).Info("TriggerRunner finalized")
 	}
 }
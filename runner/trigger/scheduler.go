@@ -0,0 +1,230 @@
+package trigger
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+var (
+	ErrUndefinedSchedule = errors.New("either a cron expression or an interval must be defined")
+	ErrInvalidInterval   = errors.New("the interval must be greater than zero")
+	ErrInvalidJitter     = errors.New("the jitter cannot be negative")
+)
+
+const _schedulerLoggerName = "[SCHEDULER]"
+
+var _invalidLockKeyChars = regexp.MustCompile(`[^-_a-zA-Z0-9]+`) //nolint:gochecknoglobals // Compiled once
+
+type ScheduledFunc func(tr *Runner, sdk sdk.KaiSDK)
+
+// Schedule defines when a scheduled trigger fires.
+// Cron takes precedence over Interval when both are defined.
+type Schedule struct {
+	// Cron is a standard five-field cron expression, descriptors such as "@hourly" are also accepted.
+	Cron string
+	// Interval fires the trigger every fixed duration, aligned to the epoch so every replica shares the same ticks.
+	Interval time.Duration
+	// Jitter delays each execution by a random duration up to the given value.
+	Jitter time.Duration
+	// SkipIfRunning discards a tick when the previous execution has not finished yet.
+	SkipIfRunning bool
+	// LeaderElection ensures only one replica fires each tick by taking a lock in a NATS key-value store.
+	LeaderElection bool
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.interval).Add(s.interval)
+}
+
+type scheduler struct {
+	schedule   cron.Schedule
+	config     Schedule
+	task       ScheduledFunc
+	running    atomic.Int32
+	lockStore  nats.KeyValue
+	replicaID  string
+	done       chan struct{}
+	stopOnce   sync.Once
+	executions sync.WaitGroup
+}
+
+func newScheduler(schedule Schedule, task ScheduledFunc, js nats.JetStreamContext) (*scheduler, error) {
+	if schedule.Jitter < 0 {
+		return nil, ErrInvalidJitter
+	}
+
+	var cronSchedule cron.Schedule
+
+	switch {
+	case schedule.Cron != "":
+		parsed, err := cron.ParseStandard(schedule.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cron expression %q: %w", schedule.Cron, err)
+		}
+
+		cronSchedule = parsed
+	case schedule.Interval < 0:
+		return nil, ErrInvalidInterval
+	case schedule.Interval > 0:
+		cronSchedule = intervalSchedule{interval: schedule.Interval}
+	default:
+		return nil, ErrUndefinedSchedule
+	}
+
+	replicaID, err := os.Hostname()
+	if err != nil || replicaID == "" {
+		replicaID = uuid.New().String()
+	}
+
+	var lockStore nats.KeyValue
+
+	if schedule.LeaderElection {
+		lockStore, err = getLockStore(js)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing the scheduler lock store: %w", err)
+		}
+	}
+
+	return &scheduler{
+		schedule:  cronSchedule,
+		config:    schedule,
+		task:      task,
+		lockStore: lockStore,
+		replicaID: replicaID,
+		done:      make(chan struct{}),
+	}, nil
+}
+
+func (s *scheduler) run(tr *Runner, kaiSDK sdk.KaiSDK) {
+	logger := kaiSDK.Logger.WithName(_schedulerLoggerName)
+
+	for {
+		now := time.Now()
+		tick := s.schedule.Next(now)
+
+		logger.V(1).Info(fmt.Sprintf("Next execution scheduled at %s", tick.Format(time.RFC3339)))
+
+		timer := time.NewTimer(tick.Sub(now) + s.getJitter())
+
+		select {
+		case <-s.done:
+			timer.Stop()
+			logger.V(1).Info("Scheduler stopped")
+
+			return
+		case <-timer.C:
+			s.fire(tr, kaiSDK, tick)
+		}
+	}
+}
+
+// stop stops scheduling executions and waits for the running ones to finish.
+func (s *scheduler) stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+
+	s.executions.Wait()
+}
+
+func (s *scheduler) fire(tr *Runner, kaiSDK sdk.KaiSDK, tick time.Time) {
+	logger := kaiSDK.Logger.WithName(_schedulerLoggerName)
+
+	if s.config.SkipIfRunning && s.running.Load() > 0 {
+		logger.Info(fmt.Sprintf("Skipping execution scheduled at %s, previous execution still running",
+			tick.Format(time.RFC3339)))
+
+		return
+	}
+
+	if s.config.LeaderElection {
+		acquired, err := s.acquireLock(kaiSDK, tick)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Error acquiring lock for execution scheduled at %s", tick.Format(time.RFC3339)))
+			return
+		}
+
+		if !acquired {
+			logger.V(1).Info(fmt.Sprintf("Execution scheduled at %s is handled by another replica",
+				tick.Format(time.RFC3339)))
+
+			return
+		}
+	}
+
+	// Executions overlap unless skipped, so the running ones are counted instead of flagged.
+	s.running.Add(1)
+	s.executions.Add(1)
+
+	go func() {
+		defer s.executions.Done()
+		defer s.running.Add(-1)
+
+		logger.V(1).Info(fmt.Sprintf("Executing task scheduled at %s", tick.Format(time.RFC3339)))
+		s.task(tr, kaiSDK)
+		logger.V(1).Info(fmt.Sprintf("Task scheduled at %s executed", tick.Format(time.RFC3339)))
+	}()
+}
+
+func (s *scheduler) acquireLock(kaiSDK sdk.KaiSDK, tick time.Time) (bool, error) {
+	key := fmt.Sprintf("%s.%s.%s.%s.%d",
+		sanitizeLockKey(kaiSDK.Metadata.GetProduct()),
+		sanitizeLockKey(kaiSDK.Metadata.GetVersion()),
+		sanitizeLockKey(kaiSDK.Metadata.GetWorkflow()),
+		sanitizeLockKey(kaiSDK.Metadata.GetProcess()),
+		tick.Unix(),
+	)
+
+	_, err := s.lockStore.Create(key, []byte(s.replicaID))
+	if errors.Is(err, nats.ErrKeyExists) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func getLockStore(js nats.JetStreamContext) (nats.KeyValue, error) {
+	bucket := viper.GetString(common.ConfigRunnerSchedulerLockBucketKey)
+
+	kv, err := js.KeyValue(bucket)
+	if errors.Is(err, nats.ErrBucketNotFound) {
+		return js.CreateKeyValue(&nats.KeyValueConfig{
+			Bucket: bucket,
+			TTL:    viper.GetDuration(common.ConfigRunnerSchedulerLockTTLKey),
+		})
+	}
+
+	return kv, err
+}
+
+func (s *scheduler) getJitter() time.Duration {
+	if s.config.Jitter <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int64N(int64(s.config.Jitter))) //nolint:gosec // Jitter does not need a secure source
+}
+
+func sanitizeLockKey(value string) string {
+	return _invalidLockKeyChars.ReplaceAllString(value, "-")
+}
@@ -0,0 +1,245 @@
+//go:build unit
+
+package trigger_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	"github.com/konstellation-io/kai-gosdk/runner/trigger"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	kaiSDK    sdk.KaiSDK
+	jetstream *mocks.JetStreamContextMock
+	lockStore *mocks.KeyValueMock
+}
+
+func (s *SchedulerTestSuite) SetupTest() {
+	viper.Reset()
+	viper.Set(common.ConfigRunnerSchedulerLockBucketKey, "scheduler-locks")
+
+	metadata := mocks.NewMetadataMock(s.T())
+	metadata.On("GetProduct").Return("some-product").Maybe()
+	metadata.On("GetVersion").Return("v1.0.0").Maybe()
+	metadata.On("GetWorkflow").Return("some-workflow").Maybe()
+	metadata.On("GetProcess").Return("some process").Maybe()
+
+	s.kaiSDK = sdk.KaiSDK{Logger: testr.New(s.T()), Metadata: metadata}
+	s.jetstream = mocks.NewJetStreamContextMock(s.T())
+	s.lockStore = mocks.NewKeyValueMock(s.T())
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
+
+func (s *SchedulerTestSuite) newScheduler(schedule trigger.Schedule, task trigger.ScheduledFunc) *trigger.Scheduler {
+	scheduler, err := trigger.NewTestScheduler(schedule, task, s.jetstream)
+	s.Require().NoError(err)
+
+	return scheduler
+}
+
+func (s *SchedulerTestSuite) TestScheduler_IntervalNext_ExpectAlignedToInterval() {
+	// Given
+	scheduler := s.newScheduler(trigger.Schedule{Interval: time.Minute}, nil)
+	now := time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC)
+
+	// When
+	next := scheduler.Next(now)
+	afterNext := scheduler.Next(next)
+
+	// Then
+	s.Equal(time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC), next)
+	s.Equal(time.Date(2024, 1, 1, 10, 2, 0, 0, time.UTC), afterNext)
+}
+
+func (s *SchedulerTestSuite) TestScheduler_CronNext_ExpectNextMatchingTime() {
+	// Given
+	scheduler := s.newScheduler(trigger.Schedule{Cron: "*/5 * * * *", Interval: time.Second}, nil)
+	now := time.Date(2024, 1, 1, 10, 2, 0, 0, time.UTC)
+
+	// When
+	next := scheduler.Next(now)
+
+	// Then
+	s.Equal(time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC), next)
+}
+
+func (s *SchedulerTestSuite) TestScheduler_GetJitter_ExpectWithinBounds() {
+	// Given
+	jitter := 50 * time.Millisecond
+	scheduler := s.newScheduler(trigger.Schedule{Interval: time.Minute, Jitter: jitter}, nil)
+	noJitterScheduler := s.newScheduler(trigger.Schedule{Interval: time.Minute}, nil)
+
+	for i := 0; i < 100; i++ {
+		// When
+		delay := scheduler.GetJitter()
+
+		// Then
+		s.GreaterOrEqual(delay, time.Duration(0))
+		s.Less(delay, jitter)
+		s.Zero(noJitterScheduler.GetJitter())
+	}
+}
+
+func (s *SchedulerTestSuite) TestScheduler_NegativeJitter_ExpectError() {
+	// When
+	_, err := trigger.NewTestScheduler(trigger.Schedule{Interval: time.Minute, Jitter: -time.Second}, nil, s.jetstream)
+
+	// Then
+	s.ErrorIs(err, trigger.ErrInvalidJitter)
+}
+
+func (s *SchedulerTestSuite) TestScheduler_SkipIfRunning_ExpectOverlappingTickSkipped() {
+	// Given
+	var executions atomic.Int32
+
+	release := make(chan struct{})
+	task := func(_ *trigger.Runner, _ sdk.KaiSDK) {
+		executions.Add(1)
+		<-release
+	}
+
+	scheduler := s.newScheduler(trigger.Schedule{Interval: time.Minute, SkipIfRunning: true}, task)
+
+	// When
+	scheduler.Fire(s.kaiSDK, time.Now())
+	scheduler.Fire(s.kaiSDK, time.Now())
+	close(release)
+	scheduler.Stop()
+
+	// Then
+	s.Equal(int32(1), executions.Load())
+}
+
+func (s *SchedulerTestSuite) TestScheduler_WithoutSkipIfRunning_ExpectOverlappingTicksExecuted() {
+	// Given
+	var executions atomic.Int32
+
+	release := make(chan struct{})
+	task := func(_ *trigger.Runner, _ sdk.KaiSDK) {
+		executions.Add(1)
+		<-release
+	}
+
+	scheduler := s.newScheduler(trigger.Schedule{Interval: time.Minute}, task)
+
+	// When
+	scheduler.Fire(s.kaiSDK, time.Now())
+	scheduler.Fire(s.kaiSDK, time.Now())
+	close(release)
+	scheduler.Stop()
+
+	// Then
+	s.Equal(int32(2), executions.Load())
+}
+
+func (s *SchedulerTestSuite) TestScheduler_Stop_ExpectRunReturns() {
+	// Given
+	var executions atomic.Int32
+
+	scheduler := s.newScheduler(trigger.Schedule{Interval: 10 * time.Millisecond},
+		func(_ *trigger.Runner, _ sdk.KaiSDK) { executions.Add(1) })
+
+	stopped := make(chan struct{})
+
+	go func() {
+		scheduler.Run(s.kaiSDK)
+		close(stopped)
+	}()
+
+	s.Eventually(func() bool { return executions.Load() > 0 }, time.Second, 5*time.Millisecond)
+
+	// When
+	scheduler.Stop()
+
+	// Then
+	s.Eventually(func() bool {
+		select {
+		case <-stopped:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *SchedulerTestSuite) TestScheduler_AcquireFreeLock_ExpectAcquired() {
+	// Given
+	tick := time.Unix(1704103200, 0)
+	s.jetstream.On("KeyValue", "scheduler-locks").Return(s.lockStore, nil)
+	s.lockStore.On("Create", "some-product.v1-0-0.some-workflow.some-process.1704103200", mock.Anything).
+		Return(uint64(1), nil)
+
+	scheduler := s.newScheduler(trigger.Schedule{Interval: time.Minute, LeaderElection: true}, nil)
+
+	// When
+	acquired, err := scheduler.AcquireLock(s.kaiSDK, tick)
+
+	// Then
+	s.Require().NoError(err)
+	s.True(acquired)
+}
+
+func (s *SchedulerTestSuite) TestScheduler_AcquireHeldLock_ExpectNotAcquired() {
+	// Given
+	s.jetstream.On("KeyValue", "scheduler-locks").Return(s.lockStore, nil)
+	s.lockStore.On("Create", mock.Anything, mock.Anything).Return(uint64(0), nats.ErrKeyExists)
+
+	var executions atomic.Int32
+
+	scheduler := s.newScheduler(trigger.Schedule{Interval: time.Minute, LeaderElection: true},
+		func(_ *trigger.Runner, _ sdk.KaiSDK) { executions.Add(1) })
+
+	// When
+	acquired, err := scheduler.AcquireLock(s.kaiSDK, time.Now())
+	scheduler.Fire(s.kaiSDK, time.Now())
+	scheduler.Stop()
+
+	// Then
+	s.Require().NoError(err)
+	s.False(acquired)
+	s.Zero(executions.Load())
+}
+
+func (s *SchedulerTestSuite) TestScheduler_LockStoreError_ExpectError() {
+	// Given
+	lockStoreErr := errors.New("some error")
+	s.jetstream.On("KeyValue", "scheduler-locks").Return(nil, lockStoreErr)
+
+	// When
+	_, err := trigger.NewTestScheduler(trigger.Schedule{Interval: time.Minute, LeaderElection: true}, nil,
+		s.jetstream)
+
+	// Then
+	s.ErrorIs(err, lockStoreErr)
+}
+
+func (s *SchedulerTestSuite) TestScheduler_LockStoreNotFound_ExpectCreated() {
+	// Given
+	s.jetstream.On("KeyValue", "scheduler-locks").Return(nil, nats.ErrBucketNotFound)
+	s.jetstream.On("CreateKeyValue", mock.MatchedBy(func(config *nats.KeyValueConfig) bool {
+		return config.Bucket == "scheduler-locks"
+	})).Return(s.lockStore, nil)
+
+	// When
+	_, err := trigger.NewTestScheduler(trigger.Schedule{Interval: time.Minute, LeaderElection: true}, nil,
+		s.jetstream)
+
+	// Then
+	s.NoError(err)
+}
@@ -10,22 +10,24 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
-	"github.com/google/uuid"
-
 	"github.com/nats-io/nats.go"
 	"github.com/spf13/viper"
-	"google.golang.org/protobuf/proto"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
 	"github.com/konstellation-io/kai-gosdk/internal/errors"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	"github.com/konstellation-io/kai-gosdk/internal/tracing"
 	kai "github.com/konstellation-io/kai-gosdk/protos"
+	runnerCommon "github.com/konstellation-io/kai-gosdk/runner/common"
 	"github.com/konstellation-io/kai-gosdk/sdk"
 )
 
-const _subscriberLoggerName = "[SUBSCRIBER]"
+const (
+	_subscriberLoggerName  = "[SUBSCRIBER]"
+	_originalSubjectHeader = "Kai-Original-Subject"
+)
 
 func (tr *Runner) getLoggerWithName() logr.Logger {
 	return tr.sdk.Logger.WithName(_subscriberLoggerName)
@@ -36,16 +38,14 @@ func (tr *Runner) startSubscriber() {
 
 	var err error
 
-	tr.messagesMetric, err = tr.sdk.Measurements.GetMetricsClient().Int64Histogram(
-		"runner-process-message-metric",
-		metric.WithDescription("How long it takes to process a message and times called."),
-		metric.WithUnit("ms"),
-	)
+	tr.metrics, err = metrics.NewRunner(tr.sdk.Measurements.GetMetricsClient())
 	if err != nil {
-		tr.getLoggerWithName().Error(err, "Error initializing metric")
+		tr.getLoggerWithName().Error(err, "Error initializing metrics")
 		os.Exit(1)
 	}
 
+	tr.publisher = runnerCommon.NewPublisher(tr.sdk, tr.nats, tr.jetstream, tr.metrics)
+
 	subscriptions := make([]*nats.Subscription, 0, len(inputSubjects))
 
 	for _, subject := range inputSubjects {
@@ -54,11 +54,12 @@ func (tr *Runner) startSubscriber() {
 
 		tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Subscribing to subject %s with queue group %s", subject, consumerName))
 
-		s, err := tr.jetstream.Subscribe(
+		s, err := tr.jetstream.QueueSubscribe(
 			subject,
+			consumerName,
 			tr.processMessage,
 			nats.DeliverNew(),
-			nats.Durable(fmt.Sprintf("%s-%s", consumerName, uuid.New().String())),
+			nats.Durable(consumerName),
 			nats.ManualAck(),
 			nats.AckWait(viper.GetDuration(common.ConfigRunnerSubscriberAckWaitTimeKey)),
 		)
@@ -73,7 +74,19 @@ func (tr *Runner) startSubscriber() {
 		tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Listening to subject %s with queue group %s", subject, consumerName))
 	}
 
+	tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Subscribing to reply subject %s", tr.replySubject))
+
+	replySubscription, err := tr.nats.Subscribe(tr.replySubject, tr.processReply)
+	if err != nil {
+		tr.getLoggerWithName().Error(err, fmt.Sprintf("Error subscribing to reply subject %s", tr.replySubject))
+		wg.Done()
+		os.Exit(1)
+	}
+
+	subscriptions = append(subscriptions, replySubscription)
+
 	tr.getLoggerWithName().V(1).Info("Subscribed to all subjects successfully")
+	tr.health.SetSubscribed(true)
 
 	// Handle sigterm and await termChan signal
 	termChan := make(chan os.Signal, 1)
@@ -82,6 +95,7 @@ func (tr *Runner) startSubscriber() {
 
 	// Handle shutdown
 	tr.getLoggerWithName().Info("Shutdown signal received")
+	tr.health.SetSubscribed(false)
 
 	tr.getLoggerWithName().V(1).Info("Unsubscribing from all subjects")
 
@@ -97,205 +111,199 @@ func (tr *Runner) startSubscriber() {
 	}
 
 	tr.getLoggerWithName().Info("Unsubscribed from all subjects")
+
+	// Wait for the messages being forwarded to be acknowledged.
+	tr.forwards.Wait()
 	wg.Done()
 }
 
 func (tr *Runner) processMessage(msg *nats.Msg) {
 	tr.getLoggerWithName().V(1).Info("New message received")
 
-	requestMsg, err := tr.newRequestMessage(msg.Data)
+	requestMsg, err := runnerCommon.NewRequestMessage(msg.Data)
 	if err != nil {
+		tr.metrics.RecordReceived(context.Background(), msg, nil, metrics.Attributes(tr.sdk.Metadata, msg.Subject, ""))
+
 		errMsg := fmt.Sprintf("Error parsing msg.data coming from subject %s because is not a valid protobuf: %s", msg.Subject, err)
-		tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
+		tr.processRunnerError(msg.Subject, errMsg, requestMsg)
+		tr.ackMessage(msg, metrics.Attributes(tr.sdk.Metadata, msg.Subject, ""))
 
 		return
 	}
 
-	start := time.Now()
-	defer func() {
-		executionTime := time.Since(start).Milliseconds()
-		tr.sdk.Logger.V(1).Info(fmt.Sprintf("%s execution time: %d ms", tr.sdk.Metadata.GetProcess(), executionTime))
-
-		tr.messagesMetric.Record(context.Background(), executionTime,
-			metric.WithAttributeSet(tr.getMetricAttributes(requestMsg.GetRequestId())),
-		)
-	}()
+	attrs := metrics.Attributes(tr.sdk.Metadata, msg.Subject, requestMsg.GetFromNode())
+	tr.metrics.RecordReceived(context.Background(), msg, requestMsg, attrs)
 
-	tr.getLoggerWithName().Info(fmt.Sprintf("New message received with subject %s",
-		msg.Subject))
+	replySubject := requestMsg.GetReplySubject()
+	if replySubject != "" && replySubject != tr.replySubject {
+		// Forwarded in the background, so waiting for a replica that is gone does not hold the other messages.
+		tr.forwards.Add(1)
 
-	if tr.responseHandler == nil {
-		errMsg := fmt.Sprintf("Error missing handler for node %q", requestMsg.GetFromNode())
-		tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
+		go func() {
+			defer tr.forwards.Done()
+			tr.forward(msg, replySubject, requestMsg.GetRequestId(), attrs)
+		}()
 
 		return
 	}
 
-	// Make a shallow copy of the sdk object to set inside the request msg.
-	hSdk := sdk.ShallowCopyWithRequest(&tr.sdk, requestMsg)
+	tr.handleMessage(msg, requestMsg)
+	tr.ackMessage(msg, attrs)
+}
 
-	err = tr.responseHandler(hSdk, requestMsg.GetPayload())
-	if err != nil {
-		errMsg := fmt.Sprintf("Error in node %q executing handler for node %q: %s",
-			tr.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
-		tr.processRunnerError(msg, errMsg, requestMsg.GetRequestId())
+// processReply handles the messages forwarded to this replica by the replica that received them from the stream.
+func (tr *Runner) processReply(msg *nats.Msg) {
+	tr.getLoggerWithName().V(1).Info("New reply received")
 
-		return
+	subject := msg.Header.Get(_originalSubjectHeader)
+	if subject == "" {
+		subject = msg.Subject
 	}
 
-	// Tell NATS we don't need to receive the message anymore, and we are done processing it.
-	ackErr := msg.Ack()
-	if ackErr != nil {
-		tr.getLoggerWithName().Error(ackErr, errors.ErrMsgAck)
-	}
-}
+	requestMsg, err := runnerCommon.NewRequestMessage(msg.Data)
+	if err != nil {
+		errMsg := fmt.Sprintf("Error parsing msg.data coming from subject %s because is not a valid protobuf: %s", subject, err)
+		tr.processRunnerError(subject, errMsg, requestMsg)
 
-func (tr *Runner) processRunnerError(msg *nats.Msg, errMsg, requestID string) {
-	ackErr := msg.Ack()
-	if ackErr != nil {
-		tr.getLoggerWithName().Error(ackErr, errors.ErrMsgAck)
+		return
 	}
 
-	tr.getLoggerWithName().V(1).Info(errMsg)
-	tr.publishError(requestID, errMsg)
-}
-
-func (tr *Runner) newRequestMessage(data []byte) (*kai.KaiNatsMessage, error) {
-	requestMsg := &kai.KaiNatsMessage{}
-
-	var err error
-	if common.IsCompressed(data) {
-		data, err = common.UncompressData(data)
+	// Confirm the reception, so the forwarding replica acknowledges the message in the stream. The replies sent
+	// straight by the exit process expect no confirmation.
+	if msg.Reply != "" {
+		err = msg.Respond(nil)
 		if err != nil {
-			tr.getLoggerWithName().Error(err, "Error reading compressed message")
-			return nil, err
+			tr.getLoggerWithName().Error(err, fmt.Sprintf("Error confirming reply with request id %q",
+				requestMsg.GetRequestId()))
 		}
 	}
 
-	err = proto.Unmarshal(data, requestMsg)
-
-	return requestMsg, err
+	// Handled as if received from the stream subject, keeping the propagated headers.
+	tr.handleMessage(&nats.Msg{Subject: subject, Header: msg.Header, Data: msg.Data}, requestMsg)
 }
 
-func (tr *Runner) publishError(requestID, errMsg string) {
-	responseMsg := &kai.KaiNatsMessage{
-		RequestId:   requestID,
-		Error:       errMsg,
-		FromNode:    viper.GetString(common.ConfigMetadataProcessIDKey),
-		MessageType: kai.MessageType_ERROR,
+// forward forwards the message to the replica waiting for it, acknowledging it once confirmed. Unconfirmed messages
+// are redelivered with a growing delay, in case the replica is back or was just slow to confirm, and discarded after
+// runner.subscriber.forward_tries deliveries, as the replica is most likely gone.
+func (tr *Runner) forward(msg *nats.Msg, replySubject, requestID string, attrs metric.MeasurementOption) {
+	err := tr.forwardMessage(msg, replySubject, requestID)
+	if err == nil {
+		tr.ackMessage(msg, attrs)
+		return
 	}
-	tr.publishResponse(responseMsg, "")
-}
 
-func (tr *Runner) publishResponse(responseMsg *kai.KaiNatsMessage, channel string) {
-	outputSubject := tr.getOutputSubject(channel)
+	numDelivered := runnerCommon.NumDelivered(msg)
+	if numDelivered >= viper.GetUint64(common.ConfigRunnerSubscriberForwardTriesKey) {
+		tr.getLoggerWithName().Error(err, fmt.Sprintf("Message with request id %q undeliverable to reply subject %s "+
+			"after %d deliveries, discarding it", requestID, replySubject, numDelivered))
+		tr.metrics.MessagesFailed.Add(context.Background(), 1, attrs)
+		tr.termMessage(msg, attrs)
 
-	outputMsg, err := proto.Marshal(responseMsg)
-	if err != nil {
-		tr.getLoggerWithName().Error(err, "Error generating output result because handler result is not a serializable Protobuf")
 		return
 	}
 
-	outputMsg, err = tr.prepareOutputMessage(outputMsg)
-	if err != nil {
-		tr.getLoggerWithName().Error(err, "Error preparing output message")
-		return
+	tr.nakMessage(msg, runnerCommon.RedeliveryDelay(
+		viper.GetDuration(common.ConfigRunnerSubscriberForwardDelayKey), numDelivered), attrs)
+}
+
+// forwardMessage sends the message to the replica waiting for it, failing if no replica confirms its reception.
+// Once confirmed, the message is delivered at most once, so it is lost if the replica stops while handling it.
+func (tr *Runner) forwardMessage(msg *nats.Msg, replySubject, requestID string) error {
+	tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Forwarding message with request id %q to reply subject %s",
+		requestID, replySubject))
+
+	// The stream subject is kept in a header, reply subjects are unique per replica.
+	forwardMsg := nats.NewMsg(replySubject)
+	forwardMsg.Data = msg.Data
+
+	for key, values := range msg.Header {
+		forwardMsg.Header[key] = values
 	}
 
-	tr.getLoggerWithName().V(1).Info(fmt.Sprintf("Publishing response with subject %s", outputSubject))
+	forwardMsg.Header.Set(_originalSubjectHeader, msg.Subject)
 
-	_, err = tr.jetstream.Publish(outputSubject, outputMsg)
+	_, err := tr.request(forwardMsg, viper.GetDuration(common.ConfigRunnerSubscriberForwardWaitKey))
 	if err != nil {
-		tr.getLoggerWithName().Error(err, "Error publishing output")
-	}
-}
+		tr.getLoggerWithName().Error(err, fmt.Sprintf("Error forwarding message with request id %q to reply subject %s",
+			requestID, replySubject))
 
-func (tr *Runner) getOutputSubject(channel string) string {
-	outputSubject := viper.GetString(common.ConfigNatsOutputKey)
-	if channel != "" {
-		return fmt.Sprintf("%s.%s", outputSubject, channel)
+		return err
 	}
 
-	return outputSubject
+	return nil
 }
 
-// prepareOutputMessage will check the length of the message and compress it if necessary.
-// Fails on compressed messages bigger than the threshold.
-func (tr *Runner) prepareOutputMessage(msg []byte) ([]byte, error) {
-	maxSize, err := tr.getMaxMessageSize()
-	if err != nil {
-		return nil, fmt.Errorf("error getting max message size: %w", err)
-	}
+func (tr *Runner) handleMessage(msg *nats.Msg, requestMsg *kai.KaiNatsMessage) {
+	subject := msg.Subject
+	attrs := metrics.Attributes(tr.sdk.Metadata, subject, requestMsg.GetFromNode())
 
-	lenMsg := int64(len(msg))
-	if lenMsg <= maxSize {
-		return msg, nil
-	}
+	tr.metrics.MessagesInFlight.Add(context.Background(), 1, attrs)
 
-	outMsg, err := common.CompressData(msg)
-	if err != nil {
-		return nil, err
-	}
+	start := time.Now()
+	defer func() {
+		executionTime := time.Since(start).Milliseconds()
+		tr.sdk.Logger.WithValues(sdk.LoggerRequestID, requestMsg.GetRequestId()).V(1).
+			Info(fmt.Sprintf("%s execution time: %d ms", tr.sdk.Metadata.GetProcess(), executionTime))
 
-	lenOutMsg := int64(len(outMsg))
-	if lenOutMsg > maxSize {
-		tr.getLoggerWithName().V(1).Info("Compressed message size %s "+
-			"exceeds maximum size allowed %s", sizeInMB(lenOutMsg), sizeInMB(maxSize))
-		return nil, errors.ErrMessageToBig
-	}
+		tr.metrics.ProcessingTime.Record(context.Background(), executionTime, attrs)
+		tr.metrics.MessagesInFlight.Add(context.Background(), -1, attrs)
+	}()
+
+	tr.getLoggerWithName().Info(fmt.Sprintf("New message received with subject %s",
+		subject))
 
-	tr.getLoggerWithName().Info(fmt.Sprintf("Message prepared with original size %s "+
-		"and compressed size %s", sizeInMB(lenMsg), sizeInMB(lenOutMsg)))
+	if tr.responseHandler == nil {
+		errMsg := fmt.Sprintf("Error missing handler for node %q", requestMsg.GetFromNode())
+		tr.processRunnerError(subject, errMsg, requestMsg)
 
-	return outMsg, nil
-}
+		return
+	}
 
-func (tr *Runner) getMaxMessageSize() (int64, error) {
-	streamInfo, err := tr.jetstream.StreamInfo(viper.GetString(common.ConfigNatsStreamKey))
+	// Make a shallow copy of the sdk object to set inside the request msg.
+	hSdk := sdk.ShallowCopyWithMsg(&tr.sdk, msg, requestMsg)
+	defer tracing.EndSpan(hSdk.GetContext())
+
+	err := tr.responseHandler(hSdk, requestMsg.GetPayload())
 	if err != nil {
-		return 0, fmt.Errorf("error getting stream's max message size: %w", err)
+		errMsg := fmt.Sprintf("Error in node %q executing handler for node %q: %s",
+			tr.sdk.Metadata.GetProcess(), requestMsg.GetFromNode(), err)
+		tr.processRunnerError(subject, errMsg, requestMsg)
+
+		return
 	}
 
-	streamMaxSize := int64(streamInfo.Config.MaxMsgSize)
-	serverMaxSize := tr.nats.MaxPayload()
+	tr.metrics.MessagesProcessed.Add(context.Background(), 1, attrs)
+}
 
-	if streamMaxSize == -1 {
-		return serverMaxSize, nil
+func (tr *Runner) ackMessage(msg *nats.Msg, attrs metric.MeasurementOption) {
+	// Tell NATS we don't need to receive the message anymore, and we are done processing it.
+	ackErr := tr.ack(msg)
+	if ackErr != nil {
+		tr.getLoggerWithName().Error(ackErr, errors.ErrMsgAck)
+		tr.metrics.AckFailures.Add(context.Background(), 1, attrs)
 	}
+}
 
-	if streamMaxSize < serverMaxSize {
-		return streamMaxSize, nil
+func (tr *Runner) nakMessage(msg *nats.Msg, delay time.Duration, attrs metric.MeasurementOption) {
+	nakErr := tr.nak(msg, delay)
+	if nakErr != nil {
+		tr.getLoggerWithName().Error(nakErr, errors.ErrMsgNak)
+		tr.metrics.AckFailures.Add(context.Background(), 1, attrs)
 	}
-
-	return serverMaxSize, nil
 }
 
-func (tr *Runner) getMetricAttributes(requestID string) attribute.Set {
-	return attribute.NewSet(
-		attribute.KeyValue{
-			Key:   "product",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetProduct()),
-		},
-		attribute.KeyValue{
-			Key:   "version",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetVersion()),
-		},
-		attribute.KeyValue{
-			Key:   "workflow",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetWorkflow()),
-		},
-		attribute.KeyValue{
-			Key:   "process",
-			Value: attribute.StringValue(tr.sdk.Metadata.GetProcess()),
-		},
-		attribute.KeyValue{
-			Key:   "request_id",
-			Value: attribute.StringValue(requestID),
-		},
-	)
+func (tr *Runner) termMessage(msg *nats.Msg, attrs metric.MeasurementOption) {
+	termErr := tr.term(msg)
+	if termErr != nil {
+		tr.getLoggerWithName().Error(termErr, errors.ErrMsgTerm)
+		tr.metrics.AckFailures.Add(context.Background(), 1, attrs)
+	}
 }
 
-func sizeInMB(size int64) string {
-	mbSize := float32(size) / 1024 / 1024
-	return fmt.Sprintf("%.1f MB", mbSize)
+func (tr *Runner) processRunnerError(subject, errMsg string, requestMsg *kai.KaiNatsMessage) {
+	tr.metrics.MessagesFailed.Add(context.Background(), 1,
+		metrics.Attributes(tr.sdk.Metadata, subject, requestMsg.GetFromNode()))
+
+	tr.getLoggerWithName().V(1).Info(errMsg)
+	tr.publisher.PublishError(requestMsg, tr.replySubject, errMsg)
 }
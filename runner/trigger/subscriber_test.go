@@ -0,0 +1,247 @@
+//go:build unit
+
+package trigger_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/runner/trigger"
+	"github.com/konstellation-io/kai-gosdk/sdk"
+)
+
+const (
+	_ownReplySubject   = "_INBOX.own-replica"
+	_otherReplySubject = "_INBOX.other-replica"
+)
+
+type SubscriberTestSuite struct {
+	suite.Suite
+	kaiSDK    sdk.KaiSDK
+	mu        sync.Mutex
+	forwarded []*nats.Msg
+	acked     int
+	nakDelays []time.Duration
+	termed    int
+}
+
+func (s *SubscriberTestSuite) SetupTest() {
+	viper.Reset()
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("../../testdata")
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("fatal error initializing configuration: %w", err))
+	}
+
+	viper.Set(common.ConfigRunnerSubscriberForwardWaitKey, time.Second)
+	viper.Set(common.ConfigRunnerSubscriberForwardTriesKey, 3)
+	viper.Set(common.ConfigRunnerSubscriberForwardDelayKey, time.Second)
+
+	js := mocks.NewJetStreamContextMock(s.T())
+	js.On("KeyValue", mock.AnythingOfType("string")).Return(mocks.NewKeyValueMock(s.T()), nil)
+	js.On("ObjectStore", mock.AnythingOfType("string")).Return(mocks.NewNatsObjectStoreMock(s.T()), nil)
+
+	s.kaiSDK = sdk.NewKaiSDK(testr.New(s.T()), nil, js)
+	s.forwarded = nil
+	s.acked = 0
+	s.nakDelays = nil
+	s.termed = 0
+}
+
+func TestSubscriberTestSuite(t *testing.T) {
+	suite.Run(t, new(SubscriberTestSuite))
+}
+
+func (s *SubscriberTestSuite) newRunner(err error) *trigger.Runner {
+	return trigger.NewTestTriggerRunner(s.kaiSDK, _ownReplySubject,
+		func(msg *nats.Msg, _ time.Duration) (*nats.Msg, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			s.forwarded = append(s.forwarded, msg)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &nats.Msg{}, nil
+		}).
+		WithAcks(
+			func(*nats.Msg) error {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+
+				s.acked++
+
+				return nil
+			},
+			func(_ *nats.Msg, delay time.Duration) error {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+
+				s.nakDelays = append(s.nakDelays, delay)
+
+				return nil
+			},
+			func(*nats.Msg) error {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+
+				s.termed++
+
+				return nil
+			},
+		)
+}
+
+// newDeliveredStreamMsg returns a stream message delivered the given times, as set in its ack subject.
+func (s *SubscriberTestSuite) newDeliveredStreamMsg(requestID string, numDelivered int) *nats.Msg {
+	msg := s.newStreamMsg(requestID)
+	msg.Sub = &nats.Subscription{}
+	msg.Reply = fmt.Sprintf("$JS.ACK.test-stream.test-consumer.%d.10.10.1700000000000000000.0", numDelivered)
+
+	return msg
+}
+
+func (s *SubscriberTestSuite) newStreamMsg(requestID string) *nats.Msg {
+	payload, err := anypb.New(wrapperspb.String("some-response"))
+	s.Require().NoError(err)
+
+	data, err := proto.Marshal(&kai.KaiNatsMessage{
+		RequestId:    requestID,
+		Payload:      payload,
+		FromNode:     "exit",
+		ReplySubject: _otherReplySubject,
+	})
+	s.Require().NoError(err)
+
+	msg := nats.NewMsg("test-stream.exit")
+	msg.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	msg.Data = data
+
+	return msg
+}
+
+func (s *SubscriberTestSuite) TestForwardMessage_ReceptionConfirmed_ExpectForwardedToReplySubject() {
+	// Given
+	triggerRunner := s.newRunner(nil)
+	msg := s.newStreamMsg("some-request")
+
+	// When
+	err := triggerRunner.ForwardMessage(msg, _otherReplySubject, "some-request")
+
+	// Then
+	s.Require().NoError(err)
+	s.Require().Len(s.forwarded, 1)
+	s.Equal(_otherReplySubject, s.forwarded[0].Subject)
+	s.Equal(msg.Data, s.forwarded[0].Data)
+	s.Equal("test-stream.exit", s.forwarded[0].Header.Get("Kai-Original-Subject"))
+	s.Equal(msg.Header.Get("traceparent"), s.forwarded[0].Header.Get("traceparent"))
+}
+
+func (s *SubscriberTestSuite) TestForwardMessage_NoResponders_ExpectError() {
+	// Given
+	triggerRunner := s.newRunner(nats.ErrNoResponders)
+
+	// When
+	err := triggerRunner.ForwardMessage(s.newStreamMsg("some-request"), _otherReplySubject, "some-request")
+
+	// Then
+	s.ErrorIs(err, nats.ErrNoResponders)
+}
+
+func (s *SubscriberTestSuite) TestForwardMessage_Timeout_ExpectError() {
+	// Given
+	triggerRunner := s.newRunner(nats.ErrTimeout)
+
+	// When
+	err := triggerRunner.ForwardMessage(s.newStreamMsg("some-request"), _otherReplySubject, "some-request")
+
+	// Then
+	s.ErrorIs(err, nats.ErrTimeout)
+}
+
+func (s *SubscriberTestSuite) TestProcessReply_ExpectResponseDelivered() {
+	// Given
+	triggerRunner := s.newRunner(nil)
+	responseChannel := triggerRunner.GetResponseChannel("some-request")
+
+	streamMsg := s.newStreamMsg("some-request")
+	reply := nats.NewMsg(_ownReplySubject)
+	reply.Data = streamMsg.Data
+	reply.Header.Set("Kai-Original-Subject", streamMsg.Subject)
+
+	// When
+	go triggerRunner.ProcessReply(reply)
+
+	// Then
+	select {
+	case response := <-responseChannel:
+		value := &wrapperspb.StringValue{}
+		s.Require().NoError(response.UnmarshalTo(value))
+		s.Equal("some-response", value.GetValue())
+	case <-time.After(time.Second):
+		s.Fail("response not delivered")
+	}
+}
+
+func (s *SubscriberTestSuite) TestProcessMessage_ForwardConfirmed_ExpectAcked() {
+	// Given
+	triggerRunner := s.newRunner(nil)
+
+	// When
+	triggerRunner.ProcessMessage(s.newDeliveredStreamMsg("some-request", 1))
+	triggerRunner.WaitForwards()
+
+	// Then
+	s.Len(s.forwarded, 1)
+	s.Equal(1, s.acked)
+	s.Empty(s.nakDelays)
+	s.Zero(s.termed)
+}
+
+func (s *SubscriberTestSuite) TestProcessMessage_ForwardFails_ExpectNakedWithGrowingDelay() {
+	// Given
+	triggerRunner := s.newRunner(nats.ErrNoResponders)
+
+	// When
+	triggerRunner.ProcessMessage(s.newDeliveredStreamMsg("some-request", 1))
+	triggerRunner.ProcessMessage(s.newDeliveredStreamMsg("some-request", 2))
+	triggerRunner.WaitForwards()
+
+	// Then
+	s.ElementsMatch([]time.Duration{time.Second, 2 * time.Second}, s.nakDelays)
+	s.Zero(s.acked)
+	s.Zero(s.termed)
+}
+
+func (s *SubscriberTestSuite) TestProcessMessage_ForwardTriesExhausted_ExpectTerminated() {
+	// Given
+	triggerRunner := s.newRunner(nats.ErrNoResponders)
+
+	// When
+	triggerRunner.ProcessMessage(s.newDeliveredStreamMsg("some-request", 3))
+	triggerRunner.WaitForwards()
+
+	// Then
+	s.Equal(1, s.termed)
+	s.Empty(s.nakDelays)
+	s.Zero(s.acked)
+}
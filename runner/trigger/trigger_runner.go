@@ -1,13 +1,17 @@
 package trigger
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
 	"github.com/konstellation-io/kai-gosdk/runner/common"
+	"github.com/konstellation-io/kai-gosdk/runner/health"
 	"github.com/konstellation-io/kai-gosdk/sdk"
+	"github.com/konstellation-io/kai-gosdk/sdk/messaging"
 	"github.com/nats-io/nats.go"
-	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -21,25 +25,65 @@ type Runner struct {
 	sdk              sdk.KaiSDK
 	nats             *nats.Conn
 	jetstream        nats.JetStreamContext
+	health           *health.Checker
 	responseHandler  ResponseHandler
 	responseChannels sync.Map
 	initializer      common.Initializer
 	runner           RunnerFunc
 	finalizer        common.Finalizer
-	messagesMetric   metric.Int64Histogram
+	metrics          *metrics.Runner
+	publisher        *common.Publisher
+	replySubject     string
+	scheduler        *scheduler
+	request          func(msg *nats.Msg, timeout time.Duration) (*nats.Msg, error)
+	forwards         sync.WaitGroup
+	// ack, nak and term acknowledge the messages received from the stream.
+	ack  func(msg *nats.Msg) error
+	nak  func(msg *nats.Msg, delay time.Duration) error
+	term func(msg *nats.Msg) error
 }
 
 var wg sync.WaitGroup //nolint:gochecknoglobals // WaitGroup is used to wait for goroutines to finish
 
 func NewTriggerRunner(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContext) *Runner {
+	// Each replica listens to its own reply subject, so the responses to the requests
+	// it originated are routed back to it regardless of the replica reading them from the stream.
+	replySubject := nats.NewInbox()
+
+	kaiSDK := sdk.NewKaiSDK(logger.WithName(_triggerLoggerName), ns, js)
+	kaiSDK.Messaging = messaging.New(kaiSDK.Logger, ns, js, nil).WithReplySubject(replySubject)
+
 	return &Runner{
-		sdk:              sdk.NewKaiSDK(logger.WithName(_triggerLoggerName), ns, js),
+		sdk:              kaiSDK,
 		nats:             ns,
 		jetstream:        js,
 		responseChannels: sync.Map{},
+		replySubject:     replySubject,
+		request:          ns.RequestMsg,
+		ack:              ackMsg,
+		nak:              nakMsg,
+		term:             termMsg,
 	}
 }
 
+func ackMsg(msg *nats.Msg) error {
+	return msg.Ack()
+}
+
+func nakMsg(msg *nats.Msg, delay time.Duration) error {
+	return msg.NakWithDelay(delay)
+}
+
+func termMsg(msg *nats.Msg) error {
+	return msg.Term()
+}
+
+// WithHealthChecker sets the checker serving the health endpoints, updated as the runner starts and stops.
+func (tr *Runner) WithHealthChecker(hc *health.Checker) *Runner {
+	tr.health = hc
+	return tr
+}
+
 func (tr *Runner) WithInitializer(initializer common.Initializer) *Runner {
 	tr.initializer = composeInitializer(initializer)
 	return tr
@@ -50,6 +94,22 @@ func (tr *Runner) WithRunner(runner RunnerFunc) *Runner {
 	return tr
 }
 
+func (tr *Runner) WithScheduledRunner(schedule Schedule, task ScheduledFunc) *Runner {
+	if task == nil {
+		panic("Undefined scheduled function")
+	}
+
+	scheduler, err := newScheduler(schedule, task, tr.jetstream)
+	if err != nil {
+		panic(fmt.Errorf("error initializing the scheduler: %w", err))
+	}
+
+	tr.scheduler = scheduler
+	tr.runner = composeRunner(scheduler.run)
+
+	return tr
+}
+
 func (tr *Runner) WithFinalizer(finalizer common.Finalizer) *Runner {
 	tr.finalizer = composeFinalizer(finalizer)
 	return tr
@@ -78,7 +138,10 @@ func (tr *Runner) Run() {
 		tr.finalizer = composeFinalizer(nil)
 	}
 
+	tr.health.Start()
+
 	tr.initializer(tr.sdk)
+	tr.health.SetInitialized()
 
 	delta := 2
 	wg.Add(delta)
@@ -89,5 +152,12 @@ func (tr *Runner) Run() {
 
 	wg.Wait()
 
+	// Scheduled executions must not outlive the finalizer.
+	if tr.scheduler != nil {
+		tr.scheduler.stop()
+	}
+
 	tr.finalizer(tr.sdk)
+
+	tr.health.Shutdown()
 }
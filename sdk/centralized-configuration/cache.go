@@ -0,0 +1,190 @@
+package centralizedconfiguration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+)
+
+type cachedConfig struct {
+	value     string
+	found     bool
+	fetchedAt time.Time
+}
+
+// configCache keeps the configs read, including the ones not found, and updates them with the changes
+// notified by the key-value store watchers. Entries older than the ttl are read again, if it is set, and the
+// oldest entries are evicted once a scope holds maxSize entries, if it is set.
+type configCache struct {
+	mu         sync.RWMutex
+	logger     logr.Logger
+	ttl        time.Duration
+	maxSize    int
+	entries    map[Scope]map[string]cachedConfig
+	generation uint64
+	watchers   []nats.KeyWatcher
+	hits       metric.Int64Counter
+	misses     metric.Int64Counter
+}
+
+func newConfigCache(logger logr.Logger, ttl time.Duration, maxSize int,
+	kvStores map[Scope]nats.KeyValue,
+) (*configCache, error) {
+	cache := &configCache{
+		logger:  logger,
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[Scope]map[string]cachedConfig),
+	}
+
+	err := cache.initMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	for scope, kv := range kvStores {
+		cache.entries[scope] = make(map[string]cachedConfig)
+
+		watcher, err := kv.WatchAll(nats.UpdatesOnly())
+		if err != nil {
+			cache.stop()
+			return nil, fmt.Errorf("failed to watch %s configuration: %w", scope, err)
+		}
+
+		cache.watchers = append(cache.watchers, watcher)
+
+		go cache.watch(scope, watcher.Updates())
+	}
+
+	return cache, nil
+}
+
+// initMetrics uses the global meter provider, set up by the measurements component.
+func (c *configCache) initMetrics() error {
+	meter := otel.Meter(metrics.MeterName)
+
+	var err error
+
+	c.hits, err = meter.Int64Counter("centralized-configuration-cache-hits",
+		metric.WithDescription("Number of configs read from the local cache."))
+	if err != nil {
+		return err
+	}
+
+	c.misses, err = meter.Int64Counter("centralized-configuration-cache-misses",
+		metric.WithDescription("Number of configs read from the key-value stores because they were not cached."))
+
+	return err
+}
+
+func (c *configCache) watch(scope Scope, updates <-chan nats.KeyValueEntry) {
+	for entry := range updates {
+		if entry == nil {
+			continue
+		}
+
+		c.mu.Lock()
+		c.generation++
+
+		// Only the configs already read are updated, the rest are read when needed.
+		if _, ok := c.entries[scope][entry.Key()]; ok {
+			c.entries[scope][entry.Key()] = cachedConfig{
+				value:     string(entry.Value()),
+				found:     entry.Operation() == nats.KeyValuePut,
+				fetchedAt: time.Now(),
+			}
+		}
+		c.mu.Unlock()
+	}
+
+	c.logger.WithName(_centralizedConfigurationLoggerName).V(1).
+		Info(fmt.Sprintf("Stopped watching %s configuration changes", scope))
+}
+
+// get returns the cached config of the key and whether it was cached, along with the cache generation
+// to be used when storing the config read.
+func (c *configCache) get(scope Scope, key string) (cachedConfig, bool, uint64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, ok := c.entries[scope][key]
+	if ok && c.ttl > 0 && time.Since(cached.fetchedAt) > c.ttl {
+		ok = false
+	}
+
+	attrs := metric.WithAttributes(attribute.String("scope", string(scope)))
+	if ok {
+		c.hits.Add(context.Background(), 1, attrs)
+	} else {
+		c.misses.Add(context.Background(), 1, attrs)
+	}
+
+	return cached, ok, c.generation
+}
+
+// set stores the config read, unless it changed since the given generation and the value read may be stale.
+func (c *configCache) set(scope Scope, key, value string, found bool, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+
+	entries := c.entries[scope]
+	if _, ok := entries[key]; !ok && c.maxSize > 0 && len(entries) >= c.maxSize {
+		c.evictOldest(entries)
+	}
+
+	entries[key] = cachedConfig{value: value, found: found, fetchedAt: time.Now()}
+}
+
+// evictOldest removes the entry read the longest time ago.
+func (c *configCache) evictOldest(entries map[string]cachedConfig) {
+	var (
+		oldestKey string
+		oldest    time.Time
+	)
+
+	for key, cached := range entries {
+		if oldestKey == "" || cached.fetchedAt.Before(oldest) {
+			oldestKey, oldest = key, cached.fetchedAt
+		}
+	}
+
+	delete(entries, oldestKey)
+}
+
+func (c *configCache) invalidate(scope Scope, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	delete(c.entries[scope], key)
+}
+
+func (c *configCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	for scope := range c.entries {
+		c.entries[scope] = make(map[string]cachedConfig)
+	}
+}
+
+func (c *configCache) stop() {
+	for _, watcher := range c.watchers {
+		_ = watcher.Stop()
+	}
+}
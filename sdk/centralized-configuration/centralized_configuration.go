@@ -3,6 +3,8 @@ package centralizedconfiguration
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
 
@@ -28,12 +30,27 @@ const (
 	ProcessScope  Scope = "process"
 )
 
+// allScopesInOrder lists the scopes from the most to the least specific, the order values are resolved in.
+var allScopesInOrder = []Scope{ //nolint:gochecknoglobals // read-only precedence order
+	ProcessScope,
+	WorkflowScope,
+	ProductScope,
+	GlobalScope,
+}
+
 type CentralizedConfiguration struct {
-	logger     logr.Logger
-	globalKv   nats.KeyValue
-	productKv  nats.KeyValue
-	workflowKv nats.KeyValue
-	processKv  nats.KeyValue
+	logger       logr.Logger
+	globalKv     nats.KeyValue
+	productKv    nats.KeyValue
+	workflowKv   nats.KeyValue
+	processKv    nats.KeyValue
+	cache        *configCache
+	secrets      *secretCipher
+	flagsMu      sync.Mutex
+	flags        *featureFlags
+	flagsErr     error
+	flagsRetryAt time.Time
+	flagsBackoff time.Duration
 }
 
 func New(logger logr.Logger, js nats.JetStreamContext) (*CentralizedConfiguration, error) {
@@ -44,13 +61,30 @@ func New(logger logr.Logger, js nats.JetStreamContext) (*CentralizedConfiguratio
 		return nil, wrapErr(err)
 	}
 
-	return &CentralizedConfiguration{
+	cc := &CentralizedConfiguration{
 		logger:     logger,
 		globalKv:   globalKv,
 		productKv:  productKv,
 		workflowKv: workflowKv,
 		processKv:  processKv,
-	}, nil
+	}
+
+	if viper.GetBool(common.ConfigCcCacheEnabledKey) {
+		cc.cache, err = newConfigCache(logger, viper.GetDuration(common.ConfigCcCacheTTLKey),
+			viper.GetInt(common.ConfigCcCacheMaxSizeKey), cc.getKVStores())
+		if err != nil {
+			logger.WithName(_centralizedConfigurationLoggerName).
+				Error(err, "Error initializing configuration cache, configs will be read from the key-value stores")
+		}
+	}
+
+	cc.secrets, err = loadSecretCipher()
+	if err != nil {
+		logger.WithName(_centralizedConfigurationLoggerName).
+			Error(err, "Error loading secrets key, secrets will not be available")
+	}
+
+	return cc, nil
 }
 
 func initKVStores(logger logr.Logger, js nats.JetStreamContext) (
@@ -124,12 +158,6 @@ func (cc *CentralizedConfiguration) GetConfig(key string, scopeOpt ...Scope) (st
 		return config, nil
 	}
 
-	allScopesInOrder := []Scope{
-		ProcessScope,
-		WorkflowScope,
-		ProductScope,
-		GlobalScope,
-	}
 	for _, scope := range allScopesInOrder {
 		config, err := cc.getConfigFromScope(key, scope)
 
@@ -155,19 +183,92 @@ func (cc *CentralizedConfiguration) SetConfig(key, value string, scopeOpt ...Sco
 		return wrapErr(fmt.Errorf("failed to set config for key %q: %w", key, err))
 	}
 
+	cc.invalidateCache(key, scopeOpt...)
+
 	return nil
 }
 
-func (cc *CentralizedConfiguration) DeleteConfig(key string, scope Scope) error {
+// DeleteConfig deletes the key from the given scope. If no scope is given, it is deleted from every scope where
+// it is set, so no value is resolved for it anymore. The global and product scopes are shared with other
+// workflows and processes, so deleting a key without a scope also removes it for them.
+func (cc *CentralizedConfiguration) DeleteConfig(key string, scopeOpt ...Scope) error {
+	if len(scopeOpt) > 0 {
+		return cc.deleteConfigFromScope(key, scopeOpt[0])
+	}
+
+	var errs []error
+
+	for _, scope := range allScopesInOrder {
+		// The key-value stores do not fail deleting missing keys, so scopes without the key are skipped
+		// instead of writing a delete marker in them.
+		_, err := cc.readConfigFromScope(key, scope)
+		if errors.Is(err, nats.ErrKeyNotFound) {
+			continue
+		}
+
+		if err == nil {
+			err = cc.deleteConfigFromScope(key, scope)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("scope %s: %w", scope, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (cc *CentralizedConfiguration) deleteConfigFromScope(key string, scope Scope) error {
 	err := cc.getScopedConfig(scope).Delete(key)
 	if err != nil {
 		return fmt.Errorf("failed to delete config for key %q: %w", key, err)
 	}
 
+	cc.invalidateCache(key, scope)
+
 	return nil
 }
 
+// Refresh discards the cached configs, so they are read again from the key-value stores.
+func (cc *CentralizedConfiguration) Refresh() {
+	if cc.cache != nil {
+		cc.cache.clear()
+	}
+}
+
+func (cc *CentralizedConfiguration) invalidateCache(key string, scopeOpt ...Scope) {
+	if cc.cache != nil {
+		cc.cache.invalidate(getScope(scopeOpt...), key)
+	}
+}
+
 func (cc *CentralizedConfiguration) getConfigFromScope(key string, scope Scope) (string, error) {
+	if cc.cache == nil {
+		return cc.readConfigFromScope(key, scope)
+	}
+
+	cached, ok, generation := cc.cache.get(scope, key)
+	if ok && cached.found {
+		return cached.value, nil
+	}
+
+	if ok {
+		return "", fmt.Errorf("failed to get config for key %q: %w", key, nats.ErrKeyNotFound)
+	}
+
+	value, err := cc.readConfigFromScope(key, scope)
+
+	switch {
+	case err == nil:
+		cc.cache.set(scope, key, value, true, generation)
+	case errors.Is(err, nats.ErrKeyNotFound):
+		cc.cache.set(scope, key, "", false, generation)
+	}
+
+	return value, err
+}
+
+func (cc *CentralizedConfiguration) readConfigFromScope(key string, scope Scope) (string, error) {
 	value, err := cc.getScopedConfig(scope).Get(key)
 	if err != nil {
 		return "", fmt.Errorf("failed to get config for key %q: %w", key, err)
@@ -177,20 +278,28 @@ func (cc *CentralizedConfiguration) getConfigFromScope(key string, scope Scope)
 }
 
 func (cc *CentralizedConfiguration) getScopedConfig(scope ...Scope) nats.KeyValue {
+	return cc.getKVStores()[getScope(scope...)]
+}
+
+func (cc *CentralizedConfiguration) getKVStores() map[Scope]nats.KeyValue {
+	return map[Scope]nats.KeyValue{
+		GlobalScope:   cc.globalKv,
+		ProductScope:  cc.productKv,
+		WorkflowScope: cc.workflowKv,
+		ProcessScope:  cc.processKv,
+	}
+}
+
+// getScope returns the given scope, the process scope if undefined or unknown.
+func getScope(scope ...Scope) Scope {
 	if len(scope) == 0 {
-		return cc.processKv
+		return ProcessScope
 	}
 
 	switch scope[0] {
-	case GlobalScope:
-		return cc.globalKv
-	case ProductScope:
-		return cc.productKv
-	case WorkflowScope:
-		return cc.workflowKv
-	case ProcessScope:
-		return cc.processKv
+	case GlobalScope, ProductScope, WorkflowScope, ProcessScope:
+		return scope[0]
 	default:
-		return cc.processKv
+		return ProcessScope
 	}
 }
@@ -0,0 +1,189 @@
+//go:build unit
+
+package centralizedconfiguration_test
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+)
+
+// newCachedConfig returns a configuration with cache, along with the channel sending the process changes.
+func (s *SdkCentralizedConfigurationTestSuite) newCachedConfig(
+	ttl time.Duration,
+) (*centralizedConfiguration.CentralizedConfiguration, chan nats.KeyValueEntry) {
+	return s.newCachedConfigWithSize(ttl, 100)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) newCachedConfigWithSize(
+	ttl time.Duration, maxSize int,
+) (*centralizedConfiguration.CentralizedConfiguration, chan nats.KeyValueEntry) {
+	var processUpdates chan nats.KeyValueEntry
+
+	for _, kv := range []*mocks.KeyValueMock{&s.globalKv, &s.productKv, &s.workflowKv, &s.processKv} {
+		updates := make(chan nats.KeyValueEntry, 1)
+		if kv == &s.processKv {
+			processUpdates = updates
+		}
+
+		watcher := mocks.NewKeyWatcherMock(s.T())
+		watcher.On("Updates").Return((<-chan nats.KeyValueEntry)(updates))
+
+		kv.On("WatchAll", mock.Anything).Return(watcher, nil)
+	}
+
+	config, err := centralizedConfiguration.NewCachedBuilder(s.logger, ttl, maxSize,
+		&s.globalKv, &s.productKv, &s.workflowKv, &s.processKv)
+	s.Require().NoError(err)
+
+	return config, processUpdates
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigWithCache_ExpectSingleRead() {
+	// Given
+	config, _ := s.newCachedConfig(time.Minute)
+	s.processKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil).Once()
+
+	// When
+	first, errFirst := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+	second, errSecond := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+
+	// Then
+	s.NoError(errFirst)
+	s.NoError(errSecond)
+	s.Equal("value1", first)
+	s.Equal("value1", second)
+	s.processKv.AssertNumberOfCalls(s.T(), "Get", 1)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigWithCache_ExpectMissingKeysCached() {
+	// Given
+	config, _ := s.newCachedConfig(time.Minute)
+	s.processKv.On("Get", "key1").Return(nil, nats.ErrKeyNotFound).Once()
+	s.workflowKv.On("Get", "key1").Return(nil, nats.ErrKeyNotFound).Once()
+	s.productKv.On("Get", "key1").Return(nil, nats.ErrKeyNotFound).Once()
+	s.globalKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil).Once()
+
+	// When
+	_, errFirst := config.GetConfig("key1")
+	value, errSecond := config.GetConfig("key1")
+
+	// Then
+	s.NoError(errFirst)
+	s.NoError(errSecond)
+	s.Equal("value1", value)
+	s.processKv.AssertNumberOfCalls(s.T(), "Get", 1)
+	s.globalKv.AssertNumberOfCalls(s.T(), "Get", 1)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigWithCache_ExpectWatchedChanges() {
+	// Given
+	config, processUpdates := s.newCachedConfig(time.Minute)
+	s.processKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil).Once()
+
+	_, err := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+	s.Require().NoError(err)
+
+	// When
+	processUpdates <- s.newEntry("key1", "value2", nats.KeyValuePut)
+
+	// Then
+	s.Eventually(func() bool {
+		value, err := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+		return err == nil && value == "value2"
+	}, time.Second, 10*time.Millisecond)
+
+	processUpdates <- s.newEntry("key1", "", nats.KeyValueDelete)
+
+	s.Eventually(func() bool {
+		_, err := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	s.processKv.AssertNumberOfCalls(s.T(), "Get", 1)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigWithCache_WhenStale_ExpectReadAgain() {
+	// Given
+	config, _ := s.newCachedConfig(time.Millisecond)
+	s.processKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil).Twice()
+
+	_, err := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+	s.Require().NoError(err)
+
+	// When
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+
+	// Then
+	s.NoError(err)
+	s.processKv.AssertNumberOfCalls(s.T(), "Get", 2)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_RefreshAndSetConfig_ExpectCacheInvalidated() {
+	// Given
+	config, _ := s.newCachedConfig(time.Minute)
+	s.processKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil).Times(3)
+	s.processKv.On("PutString", "key1", "value2").Return(uint64(1), nil)
+
+	_, err := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+	s.Require().NoError(err)
+
+	// When
+	config.Refresh()
+	_, errRefresh := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+
+	errSet := config.SetConfig("key1", "value2")
+	_, errAfterSet := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+
+	// Then
+	s.NoError(errRefresh)
+	s.NoError(errSet)
+	s.NoError(errAfterSet)
+	s.processKv.AssertNumberOfCalls(s.T(), "Get", 3)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigWithCache_WhenFull_ExpectOldestEvicted() {
+	// Given
+	config, _ := s.newCachedConfigWithSize(time.Minute, 2)
+	s.processKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil).Twice()
+	s.processKv.On("Get", "key2").Return(s.newConfigEntry("value2"), nil).Once()
+	s.processKv.On("Get", "key3").Return(s.newConfigEntry("value3"), nil).Once()
+
+	for _, key := range []string{"key1", "key2", "key3"} {
+		_, err := config.GetConfig(key, centralizedConfiguration.ProcessScope)
+		s.Require().NoError(err)
+	}
+
+	// When
+	_, errEvicted := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+	_, errCached := config.GetConfig("key3", centralizedConfiguration.ProcessScope)
+
+	// Then
+	s.NoError(errEvicted)
+	s.NoError(errCached)
+	s.processKv.AssertNumberOfCalls(s.T(), "Get", 4)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_WatchedChangeOfUnreadKey_ExpectNotCached() {
+	// Given
+	config, processUpdates := s.newCachedConfig(time.Minute)
+	s.processKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil).Once()
+
+	// When
+	processUpdates <- s.newEntry("key1", "value2", nats.KeyValuePut)
+
+	// Then
+	s.Eventually(func() bool {
+		return len(processUpdates) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	value, err := config.GetConfig("key1", centralizedConfiguration.ProcessScope)
+	s.Require().NoError(err)
+	s.Equal("value1", value)
+	s.processKv.AssertNumberOfCalls(s.T(), "Get", 1)
+}
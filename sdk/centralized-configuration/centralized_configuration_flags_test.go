@@ -0,0 +1,180 @@
+//go:build unit
+
+package centralizedconfiguration_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+)
+
+const _flagsPattern = "flags.>"
+
+// mockFlags makes every scope watch the flags, setting the given initial values in the global and process scopes.
+// It returns the channel to send updates of the process scope.
+func (s *SdkCentralizedConfigurationTestSuite) mockFlags(global, process map[string]string) chan nats.KeyValueEntry {
+	entries := func(flags map[string]string) []nats.KeyValueEntry {
+		result := make([]nats.KeyValueEntry, 0, len(flags))
+		for key, value := range flags {
+			result = append(result, s.newEntry(key, value, nats.KeyValuePut))
+		}
+
+		return result
+	}
+
+	s.mockWatcher(&s.globalKv, _flagsPattern, entries(global)...)
+	s.mockWatcher(&s.productKv, _flagsPattern)
+	s.mockWatcher(&s.workflowKv, _flagsPattern)
+
+	return s.mockWatcher(&s.processKv, _flagsPattern, entries(process)...)
+}
+
+// enabledRatio returns the ratio of the request keys the flag is enabled for.
+func (s *SdkCentralizedConfigurationTestSuite) enabledRatio(isEnabled func(flag, requestKey string) bool,
+	flag string, requests int,
+) float64 {
+	enabled := 0
+
+	for i := range requests {
+		if isEnabled(flag, fmt.Sprintf("request-%d", i)) {
+			enabled++
+		}
+	}
+
+	return float64(enabled) / float64(requests)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_IsEnabled_ExpectResolvedByScope() {
+	// Given
+	s.mockFlags(
+		map[string]string{"flags.new-model": "true", "flags.fast-path": `{"enabled": true}`},
+		map[string]string{"flags.new-model": "false"},
+	)
+	config := s.newConfig()
+
+	// When
+	newModel := config.IsEnabled("new-model", "request-1")
+	fastPath := config.IsEnabled("fast-path", "request-1")
+	undefined := config.IsEnabled("undefined", "request-1")
+
+	// Then
+	s.False(newModel)
+	s.True(fastPath)
+	s.False(undefined)
+	s.processKv.AssertNumberOfCalls(s.T(), "Watch", 1)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_IsEnabledWithRollout_ExpectDeterministicPercentage() {
+	// Given
+	s.mockFlags(map[string]string{"flags.new-model": `{"enabled": true, "rollout": 25}`}, nil)
+	config := s.newConfig()
+
+	// When
+	ratio := s.enabledRatio(config.IsEnabled, "new-model", 10000)
+
+	// Then
+	s.InDelta(0.25, ratio, 0.02)
+
+	for i := range 100 {
+		requestKey := fmt.Sprintf("request-%d", i)
+		s.Equal(config.IsEnabled("new-model", requestKey), config.IsEnabled("new-model", requestKey))
+	}
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetVariant_ExpectWeightedDeterministicVariants() {
+	// Given
+	s.mockFlags(map[string]string{
+		"flags.model": `{"enabled": true, "variants": {"control": 1, "candidate": 3, "disabled": 0}}`,
+	}, nil)
+	config := s.newConfig()
+
+	// When
+	counts := make(map[string]int)
+
+	for i := range 10000 {
+		requestKey := fmt.Sprintf("request-%d", i)
+		variant := config.GetVariant("model", requestKey)
+		counts[variant]++
+
+		s.Require().Equal(variant, config.GetVariant("model", requestKey))
+	}
+
+	// Then
+	s.Len(counts, 2)
+	s.InDelta(2500, counts["control"], 200)
+	s.InDelta(7500, counts["candidate"], 200)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetVariantOfDisabledFlag_ExpectNoVariant() {
+	// Given
+	s.mockFlags(map[string]string{
+		"flags.model":   `{"enabled": false, "variants": {"control": 1, "candidate": 1}}`,
+		"flags.partial": `{"enabled": true, "rollout": 0, "variants": {"control": 1}}`,
+		"flags.plain":   "true",
+	}, nil)
+	config := s.newConfig()
+
+	// When - Then
+	s.Empty(config.GetVariant("model", "request-1"))
+	s.Empty(config.GetVariant("partial", "request-1"))
+	s.Empty(config.GetVariant("plain", "request-1"))
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_FlagChanged_ExpectEvaluatedWithNewDefinition() {
+	// Given
+	processUpdates := s.mockFlags(map[string]string{"flags.new-model": "false"}, nil)
+	config := s.newConfig()
+	s.Require().False(config.IsEnabled("new-model", "request-1"))
+
+	// When
+	processUpdates <- s.newEntry("flags.new-model", "true", nats.KeyValuePut)
+
+	// Then
+	s.Eventually(func() bool { return config.IsEnabled("new-model", "request-1") }, _watchTimeout, 10*time.Millisecond)
+
+	// When
+	processUpdates <- s.newEntry("flags.new-model", "", nats.KeyValueDelete)
+
+	// Then
+	s.Eventually(func() bool { return !config.IsEnabled("new-model", "request-1") }, _watchTimeout, 10*time.Millisecond)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_InvalidFlag_ExpectDisabled() {
+	// Given
+	s.mockFlags(map[string]string{"flags.new-model": `{"enabled": tru`}, nil)
+	config := s.newConfig()
+
+	// When - Then
+	s.False(config.IsEnabled("new-model", "request-1"))
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_FlagsNotWatched_ExpectDisabled() {
+	// Given
+	s.processKv.On("Watch", _flagsPattern).Return(nil, errors.New("connection closed"))
+	config := s.newConfig()
+
+	// When - Then
+	s.False(config.IsEnabled("new-model", "request-1"))
+	s.Empty(config.GetVariant("new-model", "request-1"))
+	s.processKv.AssertNumberOfCalls(s.T(), "Watch", 1)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_FlagsWatchedAfterBackoff_ExpectEnabled() {
+	// Given
+	s.processKv.On("Watch", _flagsPattern).Return(nil, errors.New("connection closed")).Once()
+	s.mockFlags(map[string]string{"flags.new-model": "true"}, nil)
+	config := s.newConfig()
+
+	s.False(config.IsEnabled("new-model", "request-1"))
+
+	// When
+	centralizedConfiguration.ExpireFlagsRetry(config)
+
+	// Then
+	s.True(config.IsEnabled("new-model", "request-1"))
+	s.processKv.AssertNumberOfCalls(s.T(), "Watch", 2)
+}
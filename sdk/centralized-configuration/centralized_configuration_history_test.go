@@ -0,0 +1,224 @@
+//go:build unit
+
+package centralizedconfiguration_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+)
+
+func (s *SdkCentralizedConfigurationTestSuite) newRevisionEntry(key, value string, revision uint64,
+	created time.Time, op nats.KeyValueOp,
+) nats.KeyValueEntry {
+	entry := mocks.NewKeyValueEntryMock(s.T())
+	entry.On("Key").Return(key)
+	entry.On("Revision").Return(revision)
+	entry.On("Created").Return(created)
+	entry.On("Operation").Return(op)
+	entry.On("Value").Return([]byte(value)).Maybe()
+
+	return entry
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_ListConfigWithoutScope_ExpectKeysOfEveryScope() {
+	// Given
+	s.processKv.On("Keys").Return([]string{"threshold", "model"}, nil)
+	s.workflowKv.On("Keys").Return(nil, nats.ErrNoKeysFound)
+	s.productKv.On("Keys").Return([]string{"model"}, nil)
+	s.globalKv.On("Keys").Return([]string{"endpoint"}, nil)
+	config := s.newConfig()
+
+	// When
+	keys, err := config.ListConfig()
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal([]string{"endpoint", "model", "threshold"}, keys)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_ListConfigOnEmptyScope_ExpectNoKeys() {
+	// Given
+	s.productKv.On("Keys").Return(nil, nats.ErrNoKeysFound)
+	config := s.newConfig()
+
+	// When
+	keys, err := config.ListConfig(centralizedConfiguration.ProductScope)
+
+	// Then
+	s.Require().NoError(err)
+	s.Empty(keys)
+	s.processKv.AssertNotCalled(s.T(), "Keys")
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_ListConfigFails_ExpectError() {
+	// Given
+	s.processKv.On("Keys").Return(nil, errors.New("connection closed"))
+	config := s.newConfig()
+
+	// When
+	keys, err := config.ListConfig(centralizedConfiguration.ProcessScope)
+
+	// Then
+	s.Error(err)
+	s.Nil(keys)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigHistory_ExpectRevisions() {
+	// Given
+	created := time.Now()
+	s.workflowKv.On("History", "threshold").Return([]nats.KeyValueEntry{
+		s.newRevisionEntry("threshold", "0.5", 1, created, nats.KeyValuePut),
+		s.newRevisionEntry("threshold", "", 2, created, nats.KeyValueDelete),
+		s.newRevisionEntry("threshold", "0.7", 3, created, nats.KeyValuePut),
+	}, nil)
+	config := s.newConfig()
+
+	// When
+	history, err := config.GetConfigHistory("threshold", centralizedConfiguration.WorkflowScope)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal([]centralizedConfiguration.ConfigRevision{
+		{Key: "threshold", Value: "0.5", Scope: centralizedConfiguration.WorkflowScope, Revision: 1, Created: created},
+		{Key: "threshold", Scope: centralizedConfiguration.WorkflowScope, Revision: 2, Created: created, Deleted: true},
+		{Key: "threshold", Value: "0.7", Scope: centralizedConfiguration.WorkflowScope, Revision: 3, Created: created},
+	}, history)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigHistoryNotFound_ExpectError() {
+	// Given
+	s.processKv.On("History", "threshold").Return(nil, nats.ErrKeyNotFound)
+	config := s.newConfig()
+
+	// When
+	history, err := config.GetConfigHistory("threshold")
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrKeyNotFound)
+	s.Nil(history)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetConfigRevision_ExpectLatestRevision() {
+	// Given
+	created := time.Now()
+	s.processKv.On("Get", "threshold").
+		Return(s.newRevisionEntry("threshold", "0.7", 3, created, nats.KeyValuePut), nil)
+	config := s.newConfig()
+
+	// When
+	revision, err := config.GetConfigRevision("threshold")
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(centralizedConfiguration.ConfigRevision{
+		Key: "threshold", Value: "0.7", Scope: centralizedConfiguration.ProcessScope, Revision: 3, Created: created,
+	}, revision)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_UpdateConfigWithExpectedRevision_ExpectOK() {
+	// Given
+	s.productKv.On("Update", "threshold", []byte("0.8"), uint64(3)).Return(uint64(4), nil)
+	config := s.newConfig()
+
+	// When
+	revision, err := config.UpdateConfig("threshold", "0.8", 3, centralizedConfiguration.ProductScope)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(uint64(4), revision)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_UpdateConfigWithoutRevision_ExpectCreated() {
+	// Given
+	s.processKv.On("Create", "threshold", []byte("0.8")).Return(uint64(1), nil)
+	config := s.newConfig()
+
+	// When
+	revision, err := config.UpdateConfig("threshold", "0.8", 0)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(uint64(1), revision)
+	s.processKv.AssertNotCalled(s.T(), "Update")
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_UpdateConfigChangedConcurrently_ExpectError() {
+	// Given
+	s.processKv.On("Update", "threshold", []byte("0.8"), uint64(3)).Return(uint64(0), nats.ErrKeyExists)
+	config := s.newConfig()
+
+	// When
+	revision, err := config.UpdateConfig("threshold", "0.8", 3)
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrRevisionMismatch)
+	s.Zero(revision)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_UpdateConfig_ExpectCacheInvalidated() {
+	// Given
+	s.processKv.On("Get", "threshold").Return(s.newConfigEntry("0.7"), nil).Once()
+	s.processKv.On("Update", "threshold", []byte("0.8"), uint64(3)).Return(uint64(4), nil)
+	s.processKv.On("Get", "threshold").Return(s.newConfigEntry("0.8"), nil).Once()
+	config, _ := s.newCachedConfig(time.Minute)
+
+	value, err := config.GetConfig("threshold", centralizedConfiguration.ProcessScope)
+	s.Require().NoError(err)
+	s.Require().Equal("0.7", value)
+
+	// When
+	_, err = config.UpdateConfig("threshold", "0.8", 3)
+	s.Require().NoError(err)
+
+	// Then
+	value, err = config.GetConfig("threshold", centralizedConfiguration.ProcessScope)
+	s.Require().NoError(err)
+	s.Equal("0.8", value)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_DeleteConfigWithoutScope_ExpectDeletedWhereSet() {
+	// Given
+	s.globalKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil)
+	s.productKv.On("Get", "key1").Return(nil, nats.ErrKeyNotFound)
+	s.workflowKv.On("Get", "key1").Return(nil, nats.ErrKeyNotFound)
+	s.processKv.On("Get", "key1").Return(s.newConfigEntry("value2"), nil)
+	s.globalKv.On("Delete", "key1").Return(nil)
+	s.processKv.On("Delete", "key1").Return(nil)
+	config := s.newConfig()
+
+	// When
+	err := config.DeleteConfig("key1")
+
+	// Then
+	s.Require().NoError(err)
+	s.globalKv.AssertNumberOfCalls(s.T(), "Delete", 1)
+	s.processKv.AssertNumberOfCalls(s.T(), "Delete", 1)
+	s.productKv.AssertNotCalled(s.T(), "Delete", mock.Anything)
+	s.workflowKv.AssertNotCalled(s.T(), "Delete", mock.Anything)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_DeleteConfigWithoutScopeFails_ExpectError() {
+	// Given
+	s.globalKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil)
+	s.productKv.On("Get", "key1").Return(nil, errors.New("connection closed"))
+	s.workflowKv.On("Get", "key1").Return(s.newConfigEntry("value1"), nil)
+	s.processKv.On("Get", "key1").Return(nil, nats.ErrKeyNotFound)
+	s.globalKv.On("Delete", "key1").Return(nil)
+	s.workflowKv.On("Delete", "key1").Return(errors.New("connection closed"))
+	config := s.newConfig()
+
+	// When
+	err := config.DeleteConfig("key1")
+
+	// Then
+	s.ErrorContains(err, "scope product")
+	s.ErrorContains(err, "scope workflow")
+	s.globalKv.AssertNumberOfCalls(s.T(), "Delete", 1)
+	s.productKv.AssertNotCalled(s.T(), "Delete", mock.Anything)
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+package centralizedconfiguration_test
+
+import (
+	"errors"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+)
+
+// mockScopedConfigs sets the configs of each scope, any other key is not found.
+func (s *SdkCentralizedConfigurationTestSuite) mockScopedConfigs(configs map[*mocks.KeyValueMock]map[string]string) {
+	for kv, values := range configs {
+		for key, value := range values {
+			kv.On("Get", key).Return(s.newConfigEntry(value), nil)
+		}
+	}
+
+	for _, kv := range []*mocks.KeyValueMock{&s.globalKv, &s.productKv, &s.workflowKv, &s.processKv} {
+		kv.On("Get", mock.AnythingOfType("string")).Return(nil, nats.ErrKeyNotFound).Maybe()
+	}
+}
+
+func bound(value float64) *float64 {
+	return &value
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_ValidateSchemaWithValidConfig_ExpectOK() {
+	// Given
+	s.mockScopedConfigs(map[*mocks.KeyValueMock]map[string]string{
+		&s.processKv: {"threshold": "0.75", "model": "resnet"},
+		&s.globalKv:  {"threshold": "0.5", "retries": "3", "timeout": "30s", "debug": "false", "labels": `{"a":1}`},
+	})
+	config := s.newConfig()
+
+	// When
+	err := config.ValidateSchema(centralizedConfiguration.Schema{
+		"threshold": {Type: centralizedConfiguration.FloatField, Required: true, Min: bound(0), Max: bound(1)},
+		"model":     {Required: true, Enum: []string{"resnet", "vgg"}},
+		"retries":   {Type: centralizedConfiguration.IntField, Min: bound(1)},
+		"timeout":   {Type: centralizedConfiguration.DurationField},
+		"debug":     {Type: centralizedConfiguration.BoolField},
+		"labels":    {Type: centralizedConfiguration.JSONField},
+		"optional":  {Type: centralizedConfiguration.IntField},
+	})
+
+	// Then
+	s.NoError(err)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_ValidateSchemaWithInvalidConfig_ExpectReport() {
+	// Given
+	s.mockScopedConfigs(map[*mocks.KeyValueMock]map[string]string{
+		&s.processKv:  {"threshold": "0.75", "retries": "many"},
+		&s.productKv:  {"threshold": "1.5"},
+		&s.workflowKv: {"model": "alexnet", "api_key": "plain-text"},
+	})
+	config := s.newConfig()
+
+	// When
+	err := config.ValidateSchema(centralizedConfiguration.Schema{
+		"threshold": {Type: centralizedConfiguration.FloatField, Max: bound(1)},
+		"retries":   {Type: centralizedConfiguration.IntField},
+		"model":     {Enum: []string{"resnet", "vgg"}},
+		"api_key":   {Type: centralizedConfiguration.SecretField},
+		"endpoint":  {Required: true},
+	})
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrSchemaViolation)
+
+	var validationErr *centralizedConfiguration.SchemaValidationError
+	s.Require().True(errors.As(err, &validationErr))
+	s.Equal([]string{
+		"api_key (workflow scope): value is not a secret",
+		"endpoint: required but not set in any scope",
+		`model (workflow scope): "alexnet" is not one of resnet, vgg`,
+		`retries (process scope): "many" is not an int`,
+		"threshold (product scope): 1.5 is above the maximum 1",
+	}, validationErr.Violations)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_ValidateSchemaWithUnknownType_ExpectReport() {
+	// Given
+	s.mockScopedConfigs(map[*mocks.KeyValueMock]map[string]string{
+		&s.processKv: {"threshold": "0.75"},
+	})
+	config := s.newConfig()
+
+	// When
+	err := config.ValidateSchema(centralizedConfiguration.Schema{
+		"threshold": {Type: "decimal"},
+	})
+
+	// Then
+	s.ErrorContains(err, `threshold (process scope): unknown schema type "decimal"`)
+}
@@ -0,0 +1,193 @@
+//go:build unit
+
+package centralizedconfiguration_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+)
+
+var (
+	_secretKey      = bytes.Repeat([]byte{1}, 32) //nolint:gochecknoglobals // test key
+	_otherSecretKey = bytes.Repeat([]byte{2}, 32) //nolint:gochecknoglobals // test key
+)
+
+func (s *SdkCentralizedConfigurationTestSuite) newSecretConfig(
+	secretKey []byte,
+) *centralizedConfiguration.CentralizedConfiguration {
+	config, err := centralizedConfiguration.NewSecretBuilder(s.logger, secretKey,
+		&s.globalKv, &s.productKv, &s.workflowKv, &s.processKv)
+	s.Require().NoError(err)
+
+	return config
+}
+
+// storeSecret sets the secret in the process scope and returns the value stored.
+func (s *SdkCentralizedConfigurationTestSuite) storeSecret(
+	config *centralizedConfiguration.CentralizedConfiguration, key, value string,
+) string {
+	var stored string
+
+	s.processKv.On("PutString", key, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { stored = args.String(1) }).
+		Return(uint64(1), nil).Once()
+
+	s.Require().NoError(config.SetSecret(key, value))
+
+	return stored
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_SetAndGetSecret_ExpectDecryptedValue() {
+	// Given
+	config := s.newSecretConfig(_secretKey)
+	stored := s.storeSecret(config, "api_key", "my-api-key")
+	s.mockConfigs(map[string]string{"api_key": stored})
+
+	// When
+	secret, err := config.GetSecret("api_key")
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal("my-api-key", secret.Value())
+	s.True(centralizedConfiguration.IsSecret(stored))
+	s.NotContains(stored, "my-api-key")
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetSecretOfPlainConfig_ExpectError() {
+	// Given
+	config := s.newSecretConfig(_secretKey)
+	s.mockConfigs(map[string]string{"api_key": "my-api-key"})
+
+	// When
+	_, err := config.GetSecret("api_key")
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrNotSecret)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetSecretWithOtherKey_ExpectError() {
+	// Given
+	stored := s.storeSecret(s.newSecretConfig(_otherSecretKey), "api_key", "my-api-key")
+	s.mockConfigs(map[string]string{"api_key": stored})
+	config := s.newSecretConfig(_secretKey)
+
+	// When
+	_, err := config.GetSecret("api_key")
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrSecretDecryption)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_GetSecretCopiedToOtherKey_ExpectError() {
+	// Given
+	config := s.newSecretConfig(_secretKey)
+	stored := s.storeSecret(config, "api_key", "my-api-key")
+	s.mockConfigs(map[string]string{"other_api_key": stored})
+
+	// When
+	_, err := config.GetSecret("other_api_key")
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrSecretDecryption)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_SecretsNotConfigured_ExpectError() {
+	// Given
+	config := s.newConfig()
+
+	// When
+	setErr := config.SetSecret("api_key", "my-api-key")
+	_, getErr := config.GetSecret("api_key")
+
+	// Then
+	s.ErrorIs(setErr, centralizedConfiguration.ErrSecretsNotConfigured)
+	s.ErrorIs(getErr, centralizedConfiguration.ErrSecretsNotConfigured)
+	s.processKv.AssertNotCalled(s.T(), "PutString")
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_SecretKeyFromFile_ExpectSecretsEnabled() {
+	// Given
+	keyFile := filepath.Join(s.T().TempDir(), "secrets.key")
+	s.Require().NoError(os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(_secretKey)+"\n"), 0o600))
+	viper.Set(common.ConfigCcSecretsKeyFileKey, keyFile)
+
+	s.jetstream.On(keyValue, mock.AnythingOfType("string")).Return(&s.processKv, nil)
+
+	config, err := centralizedConfiguration.New(s.logger, &s.jetstream)
+	s.Require().NoError(err)
+
+	// When
+	stored := s.storeSecret(config, "api_key", "my-api-key")
+
+	// Then
+	s.True(centralizedConfiguration.IsSecret(stored))
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_SecretKeyFromEnv_ExpectSecretsEnabled() {
+	// Given
+	s.T().Setenv("TEST_SECRETS_KEY", base64.StdEncoding.EncodeToString(_secretKey))
+	viper.Set(common.ConfigCcSecretsKeyEnvKey, "TEST_SECRETS_KEY")
+
+	s.jetstream.On(keyValue, mock.AnythingOfType("string")).Return(&s.processKv, nil)
+
+	config, err := centralizedConfiguration.New(s.logger, &s.jetstream)
+	s.Require().NoError(err)
+
+	// When
+	stored := s.storeSecret(config, "api_key", "my-api-key")
+
+	// Then
+	s.True(centralizedConfiguration.IsSecret(stored))
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_InvalidSecretKey_ExpectSecretsDisabled() {
+	// Given
+	s.T().Setenv("TEST_SECRETS_KEY", base64.StdEncoding.EncodeToString([]byte("short")))
+	viper.Set(common.ConfigCcSecretsKeyEnvKey, "TEST_SECRETS_KEY")
+
+	s.jetstream.On(keyValue, mock.AnythingOfType("string")).Return(&s.processKv, nil)
+
+	config, err := centralizedConfiguration.New(s.logger, &s.jetstream)
+	s.Require().NoError(err)
+
+	// When
+	err = config.SetSecret("api_key", "my-api-key")
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrSecretsNotConfigured)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_SecretPrinted_ExpectRedacted() {
+	// Given
+	config := s.newSecretConfig(_secretKey)
+	stored := s.storeSecret(config, "api_key", "my-api-key")
+	s.mockConfigs(map[string]string{"api_key": stored})
+
+	secret, err := config.GetSecret("api_key")
+	s.Require().NoError(err)
+
+	var logged string
+
+	logger := funcr.New(func(_, args string) { logged = args }, funcr.Options{})
+
+	// When
+	logger.Info("secret read", "secret", secret)
+	marshaled, err := json.Marshal(map[string]any{"secret": secret})
+	s.Require().NoError(err)
+
+	// Then
+	s.NotContains(logged, "my-api-key")
+	s.NotContains(string(marshaled), "my-api-key")
+	s.NotContains(fmt.Sprintf("%v %+v %#v %s", secret, secret, secret, secret), "my-api-key")
+}
@@ -0,0 +1,171 @@
+//go:build unit
+
+package centralizedconfiguration_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+)
+
+type bindTestConfig struct {
+	Threshold float64           `config:"threshold,required"`
+	Retries   int               `config:"retries" default:"3"`
+	Enabled   bool              `config:"enabled"`
+	Timeout   time.Duration     `config:"timeout,required"`
+	Model     string            `config:"model,required"`
+	Labels    map[string]string `config:"labels"`
+	Ignored   string
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) newConfigEntry(value string) nats.KeyValueEntry {
+	entry := mocks.NewKeyValueEntryMock(s.T())
+	entry.On("Value").Return([]byte(value))
+
+	return entry
+}
+
+// mockConfigs sets the process configs, any other key is not found in any scope.
+func (s *SdkCentralizedConfigurationTestSuite) mockConfigs(configs map[string]string) {
+	for key, value := range configs {
+		s.processKv.On("Get", key).Return(s.newConfigEntry(value), nil)
+	}
+
+	for _, kv := range []*mocks.KeyValueMock{&s.globalKv, &s.productKv, &s.workflowKv, &s.processKv} {
+		kv.On("Get", mock.AnythingOfType("string")).Return(nil, nats.ErrKeyNotFound).Maybe()
+	}
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) newConfig() *centralizedConfiguration.CentralizedConfiguration {
+	config, err := centralizedConfiguration.NewBuilder(s.logger, &s.globalKv, &s.productKv, &s.workflowKv, &s.processKv)
+	s.Require().NoError(err)
+
+	return config
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_TypedGetters_ExpectParsedValues() {
+	// Given
+	s.mockConfigs(map[string]string{
+		"retries":   "5",
+		"threshold": "0.75",
+		"enabled":   "true",
+		"timeout":   "1m30s",
+		"labels":    `{"team":"ml"}`,
+	})
+	config := s.newConfig()
+
+	// When
+	retries, errInt := config.GetInt("retries", 1)
+	threshold, errFloat := config.GetFloat("threshold", 0.5)
+	enabled, errBool := config.GetBool("enabled", false)
+	timeout, errDuration := config.GetDuration("timeout", time.Second)
+
+	var labels map[string]string
+	errJSON := config.GetJSON("labels", &labels)
+
+	// Then
+	s.NoError(errInt)
+	s.NoError(errFloat)
+	s.NoError(errBool)
+	s.NoError(errDuration)
+	s.NoError(errJSON)
+	s.Equal(5, retries)
+	s.InDelta(0.75, threshold, 1e-9)
+	s.True(enabled)
+	s.Equal(90*time.Second, timeout)
+	s.Equal(map[string]string{"team": "ml"}, labels)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_TypedGetters_WhenNotSet_ExpectDefaults() {
+	// Given
+	s.mockConfigs(nil)
+	config := s.newConfig()
+
+	// When
+	retries, errInt := config.GetInt("retries", 1)
+	timeout, errDuration := config.GetDuration("timeout", time.Second)
+
+	labels := map[string]string{"team": "default"}
+	errJSON := config.GetJSON("labels", &labels)
+
+	// Then
+	s.NoError(errInt)
+	s.NoError(errDuration)
+	s.NoError(errJSON)
+	s.Equal(1, retries)
+	s.Equal(time.Second, timeout)
+	s.Equal(map[string]string{"team": "default"}, labels)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_TypedGetters_WithInvalidValue_ExpectError() {
+	// Given
+	s.mockConfigs(map[string]string{"retries": "many"})
+	config := s.newConfig()
+
+	// When
+	retries, err := config.GetInt("retries", 1)
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrInvalidConfigValue)
+	s.Equal(1, retries)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_Bind_ExpectStructPopulated() {
+	// Given
+	s.mockConfigs(map[string]string{
+		"threshold": "0.9",
+		"enabled":   "true",
+		"timeout":   "10s",
+		"model":     "classifier",
+		"labels":    `{"team":"ml"}`,
+	})
+	config := s.newConfig()
+
+	var cfg bindTestConfig
+
+	// When
+	err := config.Bind(context.Background(), &cfg)
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(bindTestConfig{
+		Threshold: 0.9,
+		Retries:   3,
+		Enabled:   true,
+		Timeout:   10 * time.Second,
+		Model:     "classifier",
+		Labels:    map[string]string{"team": "ml"},
+	}, cfg)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_Bind_WithMissingAndInvalidKeys_ExpectAllReported() {
+	// Given
+	s.mockConfigs(map[string]string{"threshold": "high"})
+	config := s.newConfig()
+
+	var cfg bindTestConfig
+
+	// When
+	err := config.Bind(context.Background(), &cfg)
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrMissingConfig)
+	s.ErrorIs(err, centralizedConfiguration.ErrInvalidConfigValue)
+	s.ErrorContains(err, "timeout, model")
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_Bind_WithInvalidTarget_ExpectError() {
+	// Given
+	config := s.newConfig()
+
+	// When
+	err := config.Bind(context.Background(), bindTestConfig{})
+
+	// Then
+	s.ErrorIs(err, centralizedConfiguration.ErrInvalidBindTarget)
+}
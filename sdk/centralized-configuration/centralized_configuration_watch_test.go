@@ -0,0 +1,153 @@
+//go:build unit
+
+package centralizedconfiguration_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	centralizedConfiguration "github.com/konstellation-io/kai-gosdk/sdk/centralized-configuration"
+)
+
+const _watchTimeout = time.Second
+
+func (s *SdkCentralizedConfigurationTestSuite) newEntry(key, value string, op nats.KeyValueOp) nats.KeyValueEntry {
+	entry := mocks.NewKeyValueEntryMock(s.T())
+	entry.On("Key").Return(key).Maybe()
+	entry.On("Value").Return([]byte(value)).Maybe()
+	entry.On("Operation").Return(op).Maybe()
+
+	return entry
+}
+
+// mockWatcher makes the key-value store return a watcher sending the given initial values and then
+// the entries sent to the returned channel.
+func (s *SdkCentralizedConfigurationTestSuite) mockWatcher(kv *mocks.KeyValueMock, key string,
+	initial ...nats.KeyValueEntry,
+) chan nats.KeyValueEntry {
+	updates := make(chan nats.KeyValueEntry, len(initial)+1)
+	for _, entry := range initial {
+		updates <- entry
+	}
+
+	updates <- nil
+
+	watcher := mocks.NewKeyWatcherMock(s.T())
+	watcher.On("Updates").Return((<-chan nats.KeyValueEntry)(updates))
+	watcher.On("Stop").Return(nil).Maybe()
+
+	kv.On("Watch", key).Return(watcher, nil)
+
+	return updates
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) receive(changes <-chan centralizedConfiguration.ConfigChange,
+) centralizedConfiguration.ConfigChange {
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(_watchTimeout):
+		s.FailNow("configuration change not notified")
+	}
+
+	return centralizedConfiguration.ConfigChange{}
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_WatchConfigOnAllScopes_ExpectResolvedChanges() {
+	// Given
+	globalUpdates := s.mockWatcher(&s.globalKv, "feature.*",
+		s.newEntry("feature.threshold", "0.5", nats.KeyValuePut))
+	productUpdates := s.mockWatcher(&s.productKv, "feature.*")
+	workflowUpdates := s.mockWatcher(&s.workflowKv, "feature.*")
+	processUpdates := s.mockWatcher(&s.processKv, "feature.*")
+
+	config, err := centralizedConfiguration.NewBuilder(s.logger, &s.globalKv, &s.productKv, &s.workflowKv, &s.processKv)
+	s.Require().NoError(err)
+
+	changes := make(chan centralizedConfiguration.ConfigChange, 10)
+
+	// When
+	watcher, err := config.WatchConfig("feature.*", func(change centralizedConfiguration.ConfigChange) {
+		changes <- change
+	})
+
+	// Then
+	s.Require().NoError(err)
+
+	productUpdates <- s.newEntry("feature.threshold", "0.7", nats.KeyValuePut)
+	s.Equal(centralizedConfiguration.ConfigChange{
+		Key: "feature.threshold", Value: "0.7", Scope: centralizedConfiguration.ProductScope,
+	}, s.receive(changes))
+
+	// Overridden by the product scope, only the unrelated key is notified.
+	globalUpdates <- s.newEntry("feature.threshold", "0.6", nats.KeyValuePut)
+	globalUpdates <- s.newEntry("feature.enabled", "true", nats.KeyValuePut)
+	s.Equal(centralizedConfiguration.ConfigChange{
+		Key: "feature.enabled", Value: "true", Scope: centralizedConfiguration.GlobalScope,
+	}, s.receive(changes))
+
+	productUpdates <- s.newEntry("feature.threshold", "", nats.KeyValueDelete)
+	s.Equal(centralizedConfiguration.ConfigChange{
+		Key: "feature.threshold", Value: "0.6", Scope: centralizedConfiguration.GlobalScope,
+	}, s.receive(changes))
+
+	globalUpdates <- s.newEntry("feature.enabled", "", nats.KeyValuePurge)
+	s.Equal(centralizedConfiguration.ConfigChange{Key: "feature.enabled", Deleted: true}, s.receive(changes))
+
+	s.NoError(watcher.Stop())
+
+	for _, updates := range []chan nats.KeyValueEntry{globalUpdates, productUpdates, workflowUpdates, processUpdates} {
+		close(updates)
+	}
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_WatchConfigOnScope_ExpectScopeChanges() {
+	// Given
+	processUpdates := s.mockWatcher(&s.processKv, "key1", s.newEntry("key1", "value1", nats.KeyValuePut))
+
+	config, err := centralizedConfiguration.NewBuilder(s.logger, &s.globalKv, &s.productKv, &s.workflowKv, &s.processKv)
+	s.Require().NoError(err)
+
+	changes := make(chan centralizedConfiguration.ConfigChange, 10)
+
+	// When
+	watcher, err := config.WatchConfig("key1", func(change centralizedConfiguration.ConfigChange) {
+		changes <- change
+	}, centralizedConfiguration.ProcessScope)
+
+	// Then
+	s.Require().NoError(err)
+
+	processUpdates <- s.newEntry("key1", "value2", nats.KeyValuePut)
+	s.Equal(centralizedConfiguration.ConfigChange{
+		Key: "key1", Value: "value2", Scope: centralizedConfiguration.ProcessScope,
+	}, s.receive(changes))
+
+	processUpdates <- s.newEntry("key1", "", nats.KeyValueDelete)
+	s.Equal(centralizedConfiguration.ConfigChange{
+		Key: "key1", Scope: centralizedConfiguration.ProcessScope, Deleted: true,
+	}, s.receive(changes))
+
+	s.NoError(watcher.Stop())
+	close(processUpdates)
+}
+
+func (s *SdkCentralizedConfigurationTestSuite) TestCentralizedConfiguration_WatchConfigFails_ExpectError() {
+	// Given
+	processUpdates := s.mockWatcher(&s.processKv, "key1")
+	s.workflowKv.On("Watch", "key1").Return(nil, errors.New("watch error"))
+
+	config, err := centralizedConfiguration.NewBuilder(s.logger, &s.globalKv, &s.productKv, &s.workflowKv, &s.processKv)
+	s.Require().NoError(err)
+
+	// When
+	watcher, err := config.WatchConfig("key1", func(centralizedConfiguration.ConfigChange) {})
+
+	// Then
+	s.Error(err)
+	s.Nil(watcher)
+	close(processUpdates)
+}
@@ -3,6 +3,8 @@
 package centralizedconfiguration
 
 import (
+	"time"
+
 	"github.com/go-logr/logr"
 	"github.com/nats-io/nats.go"
 )
@@ -19,3 +21,41 @@ func NewBuilder(logger logr.Logger,
 		processKv:  processKv,
 	}, nil
 }
+
+func NewCachedBuilder(logger logr.Logger, ttl time.Duration, maxSize int,
+	globalKv, productKv, workflowKv, processKv nats.KeyValue,
+) (*CentralizedConfiguration, error) {
+	cc, _ := NewBuilder(logger, globalKv, productKv, workflowKv, processKv)
+
+	cache, err := newConfigCache(logger, ttl, maxSize, cc.getKVStores())
+	if err != nil {
+		return nil, err
+	}
+
+	cc.cache = cache
+
+	return cc, nil
+}
+
+func NewSecretBuilder(logger logr.Logger, secretKey []byte,
+	globalKv, productKv, workflowKv, processKv nats.KeyValue,
+) (*CentralizedConfiguration, error) {
+	cc, _ := NewBuilder(logger, globalKv, productKv, workflowKv, processKv)
+
+	secrets, err := newSecretCipher(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.secrets = secrets
+
+	return cc, nil
+}
+
+// ExpireFlagsRetry makes the next evaluation of a flag retry watching the flags after a failure.
+func ExpireFlagsRetry(cc *CentralizedConfiguration) {
+	cc.flagsMu.Lock()
+	defer cc.flagsMu.Unlock()
+
+	cc.flagsRetryAt = time.Time{}
+}
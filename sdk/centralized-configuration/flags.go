@@ -0,0 +1,212 @@
+package centralizedconfiguration
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	_flagKeyPrefix        = "flags."
+	_flagBuckets          = 10000
+	_fullRollout          = 100
+	_flagsMinRetryBackoff = time.Second
+	_flagsMaxRetryBackoff = time.Minute
+)
+
+// FlagDefinition is the value of a feature flag, stored as JSON in the "flags.<name>" configuration key.
+// Rollout is the percentage of request keys the flag is enabled for, all of them if undefined, and Variants
+// maps each variant to its weight. A flag can also be set to "true" or "false".
+type FlagDefinition struct {
+	Enabled  bool           `json:"enabled"`
+	Rollout  *float64       `json:"rollout,omitempty"`
+	Variants map[string]int `json:"variants,omitempty"`
+}
+
+// featureFlags keeps the flag definitions resolved by scope precedence, updated by a watcher.
+type featureFlags struct {
+	logger  logr.Logger
+	mu      sync.RWMutex
+	flags   map[string]FlagDefinition
+	watcher *Watcher
+}
+
+// IsEnabled reports whether the flag is enabled for the request key, e.g. a request id. The same request key always
+// gets the same result while the flag does not change. Undefined or invalid flags are disabled.
+func (cc *CentralizedConfiguration) IsEnabled(flag, requestKey string) bool {
+	definition, ok := cc.getFlag(flag)
+
+	return ok && definition.enabledFor(flag, requestKey)
+}
+
+// GetVariant returns the variant of the flag for the request key, picked by the weights of the variants. The same
+// request key always gets the same variant while the flag does not change. An empty variant is returned if the flag
+// is not enabled for the request key or has no variants.
+func (cc *CentralizedConfiguration) GetVariant(flag, requestKey string) string {
+	definition, ok := cc.getFlag(flag)
+	if !ok || !definition.enabledFor(flag, requestKey) {
+		return ""
+	}
+
+	return definition.variantFor(flag, requestKey)
+}
+
+func (cc *CentralizedConfiguration) getFlag(flag string) (FlagDefinition, bool) {
+	flags, err := cc.getFeatureFlags()
+	if err != nil {
+		return FlagDefinition{}, false
+	}
+
+	flags.mu.RLock()
+	defer flags.mu.RUnlock()
+
+	definition, ok := flags.flags[flag]
+
+	return definition, ok
+}
+
+// getFeatureFlags starts watching the flags the first time they are evaluated. When the watch fails, the error is
+// returned without retrying until a backoff elapses, doubled on every failure, so flags evaluated while the
+// key-value stores are unavailable do not watch them again on every request.
+func (cc *CentralizedConfiguration) getFeatureFlags() (*featureFlags, error) {
+	cc.flagsMu.Lock()
+	defer cc.flagsMu.Unlock()
+
+	if cc.flags != nil {
+		return cc.flags, nil
+	}
+
+	if cc.flagsErr != nil && time.Now().Before(cc.flagsRetryAt) {
+		return nil, cc.flagsErr
+	}
+
+	flags := &featureFlags{
+		logger: cc.logger.WithName(_centralizedConfigurationLoggerName),
+		flags:  make(map[string]FlagDefinition),
+	}
+
+	watcher, err := cc.WatchConfig(_flagKeyPrefix+">", flags.update)
+	if err != nil {
+		cc.flagsBackoff = min(max(2*cc.flagsBackoff, _flagsMinRetryBackoff), _flagsMaxRetryBackoff)
+		cc.flagsRetryAt = time.Now().Add(cc.flagsBackoff)
+		cc.flagsErr = err
+
+		flags.logger.Error(err, fmt.Sprintf("Error watching feature flags, flags are disabled until retried in %s",
+			cc.flagsBackoff))
+
+		return nil, err
+	}
+
+	// The flags set before watching are not notified, so they are taken from the values loaded by the watcher.
+	watcher.mu.Lock()
+	for _, change := range watcher.effective {
+		flags.update(change)
+	}
+	watcher.mu.Unlock()
+
+	flags.watcher = watcher
+	cc.flags = flags
+	cc.flagsErr = nil
+
+	return flags, nil
+}
+
+func (f *featureFlags) update(change ConfigChange) {
+	flag := strings.TrimPrefix(change.Key, _flagKeyPrefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if change.Deleted {
+		delete(f.flags, flag)
+		return
+	}
+
+	definition, err := parseFlag(change.Value)
+	if err != nil {
+		f.logger.Error(err, fmt.Sprintf("Invalid definition of feature flag %q in scope %s, flag is disabled",
+			flag, change.Scope))
+		delete(f.flags, flag)
+
+		return
+	}
+
+	f.flags[flag] = definition
+}
+
+func parseFlag(value string) (FlagDefinition, error) {
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		return FlagDefinition{Enabled: enabled}, nil
+	}
+
+	var definition FlagDefinition
+	if err := json.Unmarshal([]byte(value), &definition); err != nil {
+		return FlagDefinition{}, err
+	}
+
+	return definition, nil
+}
+
+func (d FlagDefinition) enabledFor(flag, requestKey string) bool {
+	if !d.Enabled {
+		return false
+	}
+
+	rollout := float64(_fullRollout)
+	if d.Rollout != nil {
+		rollout = *d.Rollout
+	}
+
+	bucket := hashRequestKey(flag, "rollout", requestKey) % _flagBuckets
+
+	return float64(bucket) < rollout*_flagBuckets/_fullRollout
+}
+
+func (d FlagDefinition) variantFor(flag, requestKey string) string {
+	variants := make([]string, 0, len(d.Variants))
+	total := 0
+
+	for variant, weight := range d.Variants {
+		if weight > 0 {
+			variants = append(variants, variant)
+			total += weight
+		}
+	}
+
+	if total == 0 {
+		return ""
+	}
+
+	// Variants are sorted so the same request key gets the same variant regardless of the map order.
+	sort.Strings(variants)
+
+	point := int(hashRequestKey(flag, "variant", requestKey) % uint64(total))
+
+	for _, variant := range variants {
+		point -= d.Variants[variant]
+		if point < 0 {
+			return variant
+		}
+	}
+
+	return variants[len(variants)-1]
+}
+
+// hashRequestKey hashes the request key for the flag, salted so rollout and variants are picked independently.
+func hashRequestKey(flag, salt, requestKey string) uint64 {
+	h := fnv.New64a()
+
+	for _, part := range []string{flag, salt, requestKey} {
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{0})
+	}
+
+	return h.Sum64()
+}
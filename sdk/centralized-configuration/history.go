@@ -0,0 +1,145 @@
+package centralizedconfiguration
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	utilErrors "github.com/konstellation-io/kai-gosdk/internal/errors"
+)
+
+var ErrRevisionMismatch = errors.New("config revision does not match the expected revision")
+
+// ConfigRevision is a value taken by a configuration key. Deleted revisions have no value.
+type ConfigRevision struct {
+	Key      string
+	Value    string
+	Scope    Scope
+	Revision uint64
+	Created  time.Time
+	Deleted  bool
+}
+
+// ListConfig returns the sorted keys set in the given scope, or in any scope if none is given.
+func (cc *CentralizedConfiguration) ListConfig(scopeOpt ...Scope) ([]string, error) {
+	wrapErr := utilErrors.Wrapper("configuration list: %w")
+
+	scopes := allScopesInOrder
+	if len(scopeOpt) > 0 {
+		scopes = []Scope{getScope(scopeOpt...)}
+	}
+
+	found := make(map[string]struct{})
+
+	for _, scope := range scopes {
+		keys, err := cc.getScopedConfig(scope).Keys()
+		if errors.Is(err, nats.ErrNoKeysFound) {
+			continue
+		} else if err != nil {
+			return nil, wrapErr(fmt.Errorf("failed to list configs in scope %s: %w", scope, err))
+		}
+
+		for _, key := range keys {
+			found[key] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(found))
+	for key := range found {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// GetConfigHistory returns the revisions kept for the key in the given scope, the process scope if undefined,
+// from the oldest to the latest. The number of revisions kept is set by the history of the key-value store.
+func (cc *CentralizedConfiguration) GetConfigHistory(key string, scopeOpt ...Scope) ([]ConfigRevision, error) {
+	wrapErr := utilErrors.Wrapper("configuration history: %w")
+
+	scope := getScope(scopeOpt...)
+
+	entries, err := cc.getScopedConfig(scope).History(key)
+	if errors.Is(err, nats.ErrKeyNotFound) {
+		return nil, wrapErr(fmt.Errorf("%w: %q", ErrKeyNotFound, key))
+	} else if err != nil {
+		return nil, wrapErr(fmt.Errorf("failed to get config history for key %q: %w", key, err))
+	}
+
+	history := make([]ConfigRevision, 0, len(entries))
+	for _, entry := range entries {
+		history = append(history, newConfigRevision(scope, entry))
+	}
+
+	return history, nil
+}
+
+// GetConfigRevision returns the current value and revision of the key in the given scope, the process scope if
+// undefined. It is always read from the key-value store, and its revision can be used to call UpdateConfig.
+func (cc *CentralizedConfiguration) GetConfigRevision(key string, scopeOpt ...Scope) (ConfigRevision, error) {
+	wrapErr := utilErrors.Wrapper("configuration get revision: %w")
+
+	scope := getScope(scopeOpt...)
+
+	entry, err := cc.getScopedConfig(scope).Get(key)
+	if errors.Is(err, nats.ErrKeyNotFound) {
+		return ConfigRevision{}, wrapErr(fmt.Errorf("%w: %q", ErrKeyNotFound, key))
+	} else if err != nil {
+		return ConfigRevision{}, wrapErr(fmt.Errorf("failed to get config for key %q: %w", key, err))
+	}
+
+	return newConfigRevision(scope, entry), nil
+}
+
+// UpdateConfig sets the key in the given scope, the process scope if undefined, only if its current revision is
+// the expected one, and returns the new revision. An expected revision of 0 only sets the key if it is not set.
+// ErrRevisionMismatch is returned if the key was changed concurrently.
+func (cc *CentralizedConfiguration) UpdateConfig(key, value string, expectedRevision uint64,
+	scopeOpt ...Scope,
+) (uint64, error) {
+	wrapErr := utilErrors.Wrapper("configuration update: %w")
+
+	kvStore := cc.getScopedConfig(scopeOpt...)
+
+	var (
+		revision uint64
+		err      error
+	)
+
+	if expectedRevision == 0 {
+		revision, err = kvStore.Create(key, []byte(value))
+	} else {
+		revision, err = kvStore.Update(key, []byte(value), expectedRevision)
+	}
+
+	if errors.Is(err, nats.ErrKeyExists) {
+		return 0, wrapErr(fmt.Errorf("%w: key %q, expected revision %d", ErrRevisionMismatch, key, expectedRevision))
+	} else if err != nil {
+		return 0, wrapErr(fmt.Errorf("failed to update config for key %q: %w", key, err))
+	}
+
+	cc.invalidateCache(key, scopeOpt...)
+
+	return revision, nil
+}
+
+func newConfigRevision(scope Scope, entry nats.KeyValueEntry) ConfigRevision {
+	revision := ConfigRevision{
+		Key:      entry.Key(),
+		Scope:    scope,
+		Revision: entry.Revision(),
+		Created:  entry.Created(),
+		Deleted:  entry.Operation() != nats.KeyValuePut,
+	}
+
+	if !revision.Deleted {
+		revision.Value = string(entry.Value())
+	}
+
+	return revision
+}
@@ -0,0 +1,166 @@
+package centralizedconfiguration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+var ErrSchemaViolation = errors.New("configuration does not match the schema")
+
+type FieldType string
+
+const (
+	StringField   FieldType = "string"
+	IntField      FieldType = "int"
+	FloatField    FieldType = "float"
+	BoolField     FieldType = "bool"
+	DurationField FieldType = "duration"
+	JSONField     FieldType = "json"
+	SecretField   FieldType = "secret"
+)
+
+// SchemaField describes a configuration key. Min and Max bound int and float values, and Enum lists the values
+// allowed. A field without type is a string.
+type SchemaField struct {
+	Type     FieldType `mapstructure:"type"`
+	Required bool      `mapstructure:"required"`
+	Min      *float64  `mapstructure:"min"`
+	Max      *float64  `mapstructure:"max"`
+	Enum     []string  `mapstructure:"enum"`
+}
+
+// Schema describes the configuration keys expected by a process.
+type Schema map[string]SchemaField
+
+// SchemaValidationError reports every violation of the schema found.
+type SchemaValidationError struct {
+	Violations []string
+}
+
+func (e *SchemaValidationError) Error() string {
+	return fmt.Sprintf("%s:\n  - %s", ErrSchemaViolation, strings.Join(e.Violations, "\n  - "))
+}
+
+func (e *SchemaValidationError) Unwrap() error {
+	return ErrSchemaViolation
+}
+
+// ValidateSchema checks the configuration against the schema. Required keys must be set in at least one scope,
+// and the value set in every scope must match the field, so no scope holds a value that would be rejected if the
+// more specific ones were deleted. A *SchemaValidationError is returned with every violation found.
+func (cc *CentralizedConfiguration) ValidateSchema(schema Schema) error {
+	keys := make([]string, 0, len(schema))
+	for key := range schema {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var violations []string
+
+	for _, key := range keys {
+		field := schema[key]
+		found := false
+
+		for _, scope := range allScopesInOrder {
+			value, err := cc.getConfigFromScope(key, scope)
+			if errors.Is(err, nats.ErrKeyNotFound) {
+				continue
+			} else if err != nil {
+				violations = append(violations, fmt.Sprintf("%s (%s scope): %s", key, scope, err))
+				continue
+			}
+
+			found = true
+
+			if err := field.validate(value); err != nil {
+				violations = append(violations, fmt.Sprintf("%s (%s scope): %s", key, scope, err))
+			}
+		}
+
+		if !found && field.Required {
+			violations = append(violations, fmt.Sprintf("%s: required but not set in any scope", key))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &SchemaValidationError{Violations: violations}
+	}
+
+	return nil
+}
+
+func (f SchemaField) validate(value string) error {
+	number, err := parseField(f.Type, value)
+	if err != nil {
+		return err
+	}
+
+	if len(f.Enum) > 0 && !slices.Contains(f.Enum, value) {
+		return fmt.Errorf("%q is not one of %s", value, strings.Join(f.Enum, ", "))
+	}
+
+	if f.Type != IntField && f.Type != FloatField {
+		return nil
+	}
+
+	if f.Min != nil && number < *f.Min {
+		return fmt.Errorf("%s is below the minimum %v", value, *f.Min)
+	}
+
+	if f.Max != nil && number > *f.Max {
+		return fmt.Errorf("%s is above the maximum %v", value, *f.Max)
+	}
+
+	return nil
+}
+
+// parseField checks the value is of the given type, returning it as a number for int and float values.
+func parseField(fieldType FieldType, value string) (float64, error) {
+	switch fieldType {
+	case "", StringField:
+		return 0, nil
+	case IntField:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an int", value)
+		}
+
+		return float64(number), nil
+	case FloatField:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a float", value)
+		}
+
+		return number, nil
+	case BoolField:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return 0, fmt.Errorf("%q is not a bool", value)
+		}
+	case DurationField:
+		if _, err := time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("%q is not a duration", value)
+		}
+	case JSONField:
+		if !json.Valid([]byte(value)) {
+			return 0, errors.New("value is not valid JSON")
+		}
+	case SecretField:
+		if !IsSecret(value) {
+			return 0, errors.New("value is not a secret")
+		}
+	default:
+		return 0, fmt.Errorf("unknown schema type %q", fieldType)
+	}
+
+	return 0, nil
+}
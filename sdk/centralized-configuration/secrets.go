@@ -0,0 +1,269 @@
+package centralizedconfiguration
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	utilErrors "github.com/konstellation-io/kai-gosdk/internal/errors"
+)
+
+var (
+	ErrSecretsNotConfigured = errors.New("secrets key not configured")
+	ErrInvalidSecretKey     = errors.New("secrets key must be a base64 encoded 32 bytes key")
+	ErrNotSecret            = errors.New("config is not a secret")
+	ErrSecretDecryption     = errors.New("secret could not be decrypted")
+)
+
+const (
+	_secretPrefix    = "kai:secret:v1:"
+	_secretKeySize   = 32
+	_secretKeyIDSize = 8
+	_redactedSecret  = "[REDACTED]"
+)
+
+// Secret is a decrypted secret value. It is redacted when printed, logged or marshaled, use Value to read it.
+type Secret struct {
+	value string
+}
+
+// Value returns the plaintext value of the secret.
+func (s Secret) Value() string {
+	return s.value
+}
+
+func (s Secret) String() string {
+	return _redactedSecret
+}
+
+func (s Secret) GoString() string {
+	return _redactedSecret
+}
+
+// MarshalLog redacts the secret in logr loggers.
+func (s Secret) MarshalLog() any {
+	return _redactedSecret
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(_redactedSecret)
+}
+
+// secretEnvelope is the stored form of a secret: the value is encrypted with a random data key, which is
+// encrypted with the secrets key identified by KeyID.
+type secretEnvelope struct {
+	KeyID   string `json:"kid"`
+	DataKey []byte `json:"key"`
+	Data    []byte `json:"data"`
+}
+
+type secretCipher struct {
+	keyID string
+	aead  cipher.AEAD
+}
+
+// SetSecret encrypts the value and sets it in the given scope, the process scope if undefined.
+func (cc *CentralizedConfiguration) SetSecret(key, value string, scopeOpt ...Scope) error {
+	wrapErr := utilErrors.Wrapper("configuration set secret: %w")
+
+	if cc.secrets == nil {
+		return wrapErr(ErrSecretsNotConfigured)
+	}
+
+	encrypted, err := cc.secrets.encrypt(key, value)
+	if err != nil {
+		return wrapErr(fmt.Errorf("failed to encrypt secret for key %q: %w", key, err))
+	}
+
+	return cc.SetConfig(key, encrypted, scopeOpt...)
+}
+
+// GetSecret returns the decrypted secret, resolved with the same precedence as GetConfig if no scope is given.
+// ErrNotSecret is returned if the config was not set with SetSecret.
+func (cc *CentralizedConfiguration) GetSecret(key string, scopeOpt ...Scope) (Secret, error) {
+	wrapErr := utilErrors.Wrapper("configuration get secret: %w")
+
+	if cc.secrets == nil {
+		return Secret{}, wrapErr(ErrSecretsNotConfigured)
+	}
+
+	value, err := cc.GetConfig(key, scopeOpt...)
+	if err != nil {
+		return Secret{}, err
+	}
+
+	decrypted, err := cc.secrets.decrypt(key, value)
+	if err != nil {
+		return Secret{}, wrapErr(fmt.Errorf("key %q: %w", key, err))
+	}
+
+	return Secret{value: decrypted}, nil
+}
+
+// IsSecret reports whether a config value was set with SetSecret.
+func IsSecret(value string) bool {
+	return strings.HasPrefix(value, _secretPrefix)
+}
+
+// loadSecretCipher reads the secrets key from the configured file or, if not set, from the configured environment
+// variable. It returns nil if no key is supplied.
+func loadSecretCipher() (*secretCipher, error) {
+	var encodedKey string
+
+	if path := viper.GetString(common.ConfigCcSecretsKeyFileKey); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading secrets key file: %w", err)
+		}
+
+		encodedKey = string(content)
+	} else if env := viper.GetString(common.ConfigCcSecretsKeyEnvKey); env != "" {
+		encodedKey = os.Getenv(env)
+	}
+
+	encodedKey = strings.TrimSpace(encodedKey)
+	if encodedKey == "" {
+		return nil, nil //nolint:nilnil // secrets are optional
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSecretKey, err)
+	}
+
+	return newSecretCipher(key)
+}
+
+func newSecretCipher(key []byte) (*secretCipher, error) {
+	if len(key) != _secretKeySize {
+		return nil, ErrInvalidSecretKey
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	keyHash := sha256.Sum256(key)
+
+	return &secretCipher{
+		keyID: hex.EncodeToString(keyHash[:_secretKeyIDSize]),
+		aead:  aead,
+	}, nil
+}
+
+// encrypt seals the value bound to the config key, so it cannot be copied to another key.
+func (c *secretCipher) encrypt(key, value string) (string, error) {
+	dataKey := make([]byte, _secretKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := seal(dataAEAD, []byte(value), []byte(key))
+	if err != nil {
+		return "", err
+	}
+
+	encryptedDataKey, err := seal(c.aead, dataKey, []byte(c.keyID))
+	if err != nil {
+		return "", err
+	}
+
+	envelope, err := json.Marshal(secretEnvelope{
+		KeyID:   c.keyID,
+		DataKey: encryptedDataKey,
+		Data:    data,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return _secretPrefix + base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+func (c *secretCipher) decrypt(key, value string) (string, error) {
+	if !IsSecret(value) {
+		return "", ErrNotSecret
+	}
+
+	rawEnvelope, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, _secretPrefix))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSecretDecryption, err)
+	}
+
+	var envelope secretEnvelope
+	if err := json.Unmarshal(rawEnvelope, &envelope); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSecretDecryption, err)
+	}
+
+	if envelope.KeyID != c.keyID {
+		return "", fmt.Errorf("%w: encrypted with key %s", ErrSecretDecryption, envelope.KeyID)
+	}
+
+	dataKey, err := open(c.aead, envelope.DataKey, []byte(c.keyID))
+	if err != nil {
+		return "", err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSecretDecryption, err)
+	}
+
+	plaintext, err := open(dataAEAD, envelope.Data, []byte(key))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext, prefixing the random nonce used.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrSecretDecryption
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretDecryption, err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,206 @@
+package centralizedconfiguration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidConfigValue = errors.New("invalid config value")
+	ErrMissingConfig      = errors.New("missing required config keys")
+	ErrInvalidBindTarget  = errors.New("the bind target must be a pointer to a struct")
+)
+
+const (
+	_configTag   = "config"
+	_defaultTag  = "default"
+	_requiredOpt = "required"
+)
+
+// GetInt returns the config of the given key as an integer, or the default value if it is not set.
+func (cc *CentralizedConfiguration) GetInt(key string, defaultValue int, scopeOpt ...Scope) (int, error) {
+	return getTyped(cc, key, defaultValue, strconv.Atoi, scopeOpt)
+}
+
+// GetFloat returns the config of the given key as a float, or the default value if it is not set.
+func (cc *CentralizedConfiguration) GetFloat(key string, defaultValue float64, scopeOpt ...Scope) (float64, error) {
+	return getTyped(cc, key, defaultValue, func(value string) (float64, error) {
+		return strconv.ParseFloat(value, 64)
+	}, scopeOpt)
+}
+
+// GetBool returns the config of the given key as a boolean, or the default value if it is not set.
+func (cc *CentralizedConfiguration) GetBool(key string, defaultValue bool, scopeOpt ...Scope) (bool, error) {
+	return getTyped(cc, key, defaultValue, strconv.ParseBool, scopeOpt)
+}
+
+// GetDuration returns the config of the given key as a duration such as "1m30s", or the default value if it is not set.
+func (cc *CentralizedConfiguration) GetDuration(key string, defaultValue time.Duration,
+	scopeOpt ...Scope,
+) (time.Duration, error) {
+	return getTyped(cc, key, defaultValue, time.ParseDuration, scopeOpt)
+}
+
+// GetJSON decodes the JSON config of the given key into the target, which is left untouched if it is not set.
+func (cc *CentralizedConfiguration) GetJSON(key string, target any, scopeOpt ...Scope) error {
+	value, found, err := cc.getOptionalConfig(key, scopeOpt...)
+	if err != nil || !found {
+		return err
+	}
+
+	err = json.Unmarshal([]byte(value), target)
+	if err != nil {
+		return fmt.Errorf("%w for key %q: %w", ErrInvalidConfigValue, key, err)
+	}
+
+	return nil
+}
+
+// Bind populates the fields of the struct pointed by target tagged with `config:"key"` with the configs
+// resolved across all scopes. Fields tagged as `config:"key,required"` without a value are reported
+// together in a single error, `default:"value"` sets the value of the fields not configured.
+// Strings, booleans, numbers and durations are parsed, any other type is decoded from JSON.
+func (cc *CentralizedConfiguration) Bind(ctx context.Context, target any) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.Elem().Kind() != reflect.Struct {
+		return ErrInvalidBindTarget
+	}
+
+	structValue := targetValue.Elem()
+	structType := structValue.Type()
+
+	var (
+		missing []string
+		errs    []error
+	)
+
+	for i := 0; i < structType.NumField(); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		field := structType.Field(i)
+
+		key, opts, _ := strings.Cut(field.Tag.Get(_configTag), ",")
+		if key == "" || key == "-" || !field.IsExported() {
+			continue
+		}
+
+		value, found, err := cc.getOptionalConfig(key)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			value, found = field.Tag.Lookup(_defaultTag)
+		}
+
+		if !found {
+			if opts == _requiredOpt {
+				missing = append(missing, key)
+			}
+
+			continue
+		}
+
+		err = setField(structValue.Field(i), value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w for key %q: %w", ErrInvalidConfigValue, key, err))
+		}
+	}
+
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrMissingConfig, strings.Join(missing, ", ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+func getTyped[T any](cc *CentralizedConfiguration, key string, defaultValue T,
+	parse func(string) (T, error), scopeOpt []Scope,
+) (T, error) {
+	value, found, err := cc.getOptionalConfig(key, scopeOpt...)
+	if err != nil || !found {
+		return defaultValue, err
+	}
+
+	parsed, err := parse(strings.TrimSpace(value))
+	if err != nil {
+		return defaultValue, fmt.Errorf("%w for key %q: %w", ErrInvalidConfigValue, key, err)
+	}
+
+	return parsed, nil
+}
+
+// getOptionalConfig returns the config of the given key and whether it is set.
+func (cc *CentralizedConfiguration) getOptionalConfig(key string, scopeOpt ...Scope) (string, bool, error) {
+	value, err := cc.GetConfig(key, scopeOpt...)
+	if errors.Is(err, ErrKeyNotFound) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
+
+func setField(field reflect.Value, value string) error {
+	trimmed := strings.TrimSpace(value)
+
+	//nolint:exhaustive // any other kind is decoded from JSON
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(trimmed)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			parsed, err := time.ParseDuration(trimmed)
+			if err != nil {
+				return err
+			}
+
+			field.SetInt(int64(parsed))
+
+			return nil
+		}
+
+		parsed, err := strconv.ParseInt(trimmed, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(trimmed, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(trimmed, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(parsed)
+	default:
+		return json.Unmarshal([]byte(value), field.Addr().Interface())
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package centralizedconfiguration
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+
+	utilErrors "github.com/konstellation-io/kai-gosdk/internal/errors"
+)
+
+// ConfigChange is a change of a configuration key. When watching every scope, the value is the one
+// resolved by precedence and Scope is the scope it comes from.
+type ConfigChange struct {
+	Key     string
+	Value   string
+	Scope   Scope
+	Deleted bool
+}
+
+type WatchCallback func(change ConfigChange)
+
+// Watcher notifies the changes of the watched configuration keys until stopped.
+type Watcher struct {
+	mu        sync.Mutex
+	callback  WatchCallback
+	watchers  []nats.KeyWatcher
+	resolve   bool
+	values    map[string]map[Scope]string
+	effective map[string]ConfigChange
+}
+
+// WatchConfig calls the callback whenever a key matching the given key or pattern (e.g. "feature.*") changes.
+// If no scope is given, every scope is watched and the callback receives the value resolved with the same
+// precedence as GetConfig, only when it changes. The current values are not notified, use GetConfig to read them.
+func (cc *CentralizedConfiguration) WatchConfig(key string, callback WatchCallback, scopeOpt ...Scope) (*Watcher, error) {
+	wrapErr := utilErrors.Wrapper("configuration watch: %w")
+
+	scopes := allScopesInOrder
+	if len(scopeOpt) > 0 {
+		scopes = []Scope{scopeOpt[0]}
+	}
+
+	w := &Watcher{
+		callback:  callback,
+		resolve:   len(scopeOpt) == 0,
+		values:    make(map[string]map[Scope]string),
+		effective: make(map[string]ConfigChange),
+	}
+
+	updates := make([]<-chan nats.KeyValueEntry, 0, len(scopes))
+
+	for _, scope := range scopes {
+		watcher, err := cc.getScopedConfig(scope).Watch(key)
+		if err != nil {
+			_ = w.Stop()
+			return nil, wrapErr(fmt.Errorf("failed to watch config for key %q in scope %s: %w", key, scope, err))
+		}
+
+		w.watchers = append(w.watchers, watcher)
+		updates = append(updates, watcher.Updates())
+
+		// The current values are loaded before watching, so changes are resolved against every scope.
+		w.loadInitialValues(scope, watcher.Updates())
+	}
+
+	for i, scope := range scopes {
+		go w.watch(scope, updates[i])
+	}
+
+	cc.logger.WithName(_centralizedConfigurationLoggerName).V(1).
+		Info(fmt.Sprintf("Watching configuration changes for key %q", key))
+
+	return w, nil
+}
+
+// Stop stops watching the configuration, no callbacks are called once it returns except the one in progress.
+func (w *Watcher) Stop() error {
+	var errs []error
+
+	for _, watcher := range w.watchers {
+		errs = append(errs, watcher.Stop())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (w *Watcher) loadInitialValues(scope Scope, updates <-chan nats.KeyValueEntry) {
+	for entry := range updates {
+		// A nil entry marks the end of the initial values.
+		if entry == nil {
+			return
+		}
+
+		w.mu.Lock()
+		w.update(scope, entry)
+		w.mu.Unlock()
+	}
+}
+
+func (w *Watcher) watch(scope Scope, updates <-chan nats.KeyValueEntry) {
+	for entry := range updates {
+		if entry != nil {
+			w.notify(scope, entry)
+		}
+	}
+}
+
+// notify calls the callback holding the lock, so changes of different scopes are notified in order.
+func (w *Watcher) notify(scope Scope, entry nats.KeyValueEntry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if change, changed := w.update(scope, entry); changed {
+		w.callback(change)
+	}
+}
+
+// update stores the entry and returns the resulting change of the key, if any.
+func (w *Watcher) update(scope Scope, entry nats.KeyValueEntry) (ConfigChange, bool) {
+	key := entry.Key()
+	deleted := entry.Operation() != nats.KeyValuePut
+
+	if !w.resolve {
+		return ConfigChange{Key: key, Value: string(entry.Value()), Scope: scope, Deleted: deleted}, true
+	}
+
+	if w.values[key] == nil {
+		w.values[key] = make(map[Scope]string)
+	}
+
+	if deleted {
+		delete(w.values[key], scope)
+	} else {
+		w.values[key][scope] = string(entry.Value())
+	}
+
+	change := ConfigChange{Key: key, Deleted: true}
+
+	for _, s := range allScopesInOrder {
+		if value, ok := w.values[key][s]; ok {
+			change = ConfigChange{Key: key, Value: value, Scope: s}
+			break
+		}
+	}
+
+	previous, known := w.effective[key]
+	w.effective[key] = change
+
+	if !known && change.Deleted {
+		return change, false
+	}
+
+	return change, previous != change
+}
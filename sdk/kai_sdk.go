@@ -2,7 +2,9 @@ package sdk
 
 import (
 	"context"
+	"io"
 	"os"
+	"time"
 
 	meta "github.com/konstellation-io/kai-gosdk/sdk/metadata"
 	"github.com/konstellation-io/kai-gosdk/sdk/prediction"
@@ -15,6 +17,7 @@ import (
 	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
 
 	"github.com/go-logr/logr"
+	"github.com/konstellation-io/kai-gosdk/internal/tracing"
 	kai "github.com/konstellation-io/kai-gosdk/protos"
 	msg "github.com/konstellation-io/kai-gosdk/sdk/messaging"
 	"github.com/nats-io/nats.go"
@@ -23,7 +26,13 @@ import (
 )
 
 const (
-	LoggerRequestID = "request_id"
+	LoggerRequestID       = "request_id"
+	LoggerTraceID         = "trace_id"
+	LoggerSpanID          = "span_id"
+	LoggerFromNode        = "from_node"
+	LoggerMessageType     = "message_type"
+	LoggerSubject         = "subject"
+	LoggerDeliveryAttempt = "delivery_attempt"
 )
 
 //go:generate mockery --name messaging --output ../mocks --filename messaging_mock.go --structname MessagingMock
@@ -72,8 +81,16 @@ type ephemeralStorage interface {
 //go:generate mockery --name persistentStorage --output ../mocks --filename persistent_storage_mock.go --structname PersistentStorageMock
 type persistentStorage interface {
 	Save(key string, value []byte, ttlDays ...int) (*persistentstorage.ObjectInfo, error)
+	SaveStream(ctx context.Context, key string, reader io.Reader, size int64,
+		opts ...persistentstorage.ObjectOption) (*persistentstorage.ObjectInfo, error)
 	Get(key string, version ...string) (*persistentstorage.Object, error)
-	List() ([]*persistentstorage.ObjectInfo, error)
+	GetStream(ctx context.Context, key, version string,
+		opts ...persistentstorage.ObjectOption) (io.ReadCloser, persistentstorage.ObjectInfo, error)
+	SetTTL(ctx context.Context, key, version string, ttlDays int) (*persistentstorage.ObjectInfo, error)
+	RemoveTTL(ctx context.Context, key, version string) error
+	List(opts ...persistentstorage.ListOption) ([]*persistentstorage.ObjectInfo, error)
+	ListPrefix(ctx context.Context, prefix string,
+		opts ...persistentstorage.ListPrefixOption) (*persistentstorage.ObjectIterator, error)
 	ListVersions(key string) ([]*persistentstorage.ObjectInfo, error)
 	Delete(key string, version ...string) error
 }
@@ -82,12 +99,39 @@ type persistentStorage interface {
 type centralizedConfig interface {
 	GetConfig(key string, scope ...centralizedConfiguration.Scope) (string, error)
 	SetConfig(key, value string, scope ...centralizedConfiguration.Scope) error
-	DeleteConfig(key string, scope centralizedConfiguration.Scope) error
+	DeleteConfig(key string, scope ...centralizedConfiguration.Scope) error
+	ListConfig(scope ...centralizedConfiguration.Scope) ([]string, error)
+	GetConfigHistory(key string, scope ...centralizedConfiguration.Scope) ([]centralizedConfiguration.ConfigRevision, error)
+	GetConfigRevision(key string, scope ...centralizedConfiguration.Scope) (centralizedConfiguration.ConfigRevision, error)
+	UpdateConfig(key, value string, expectedRevision uint64, scope ...centralizedConfiguration.Scope) (uint64, error)
+	WatchConfig(key string, callback centralizedConfiguration.WatchCallback,
+		scopeOpt ...centralizedConfiguration.Scope) (*centralizedConfiguration.Watcher, error)
+	GetInt(key string, defaultValue int, scopeOpt ...centralizedConfiguration.Scope) (int, error)
+	GetFloat(key string, defaultValue float64, scopeOpt ...centralizedConfiguration.Scope) (float64, error)
+	GetBool(key string, defaultValue bool, scopeOpt ...centralizedConfiguration.Scope) (bool, error)
+	GetDuration(key string, defaultValue time.Duration,
+		scopeOpt ...centralizedConfiguration.Scope) (time.Duration, error)
+	GetJSON(key string, target any, scopeOpt ...centralizedConfiguration.Scope) error
+	Bind(ctx context.Context, target any) error
+	SetSecret(key, value string, scope ...centralizedConfiguration.Scope) error
+	GetSecret(key string, scope ...centralizedConfiguration.Scope) (centralizedConfiguration.Secret, error)
+	ValidateSchema(schema centralizedConfiguration.Schema) error
+	IsEnabled(flag, requestKey string) bool
+	GetVariant(flag, requestKey string) string
+	Refresh()
 }
 
 //go:generate mockery --name measurements --output ../mocks --filename measurements_mock.go --structname MeasurementsMock
 type measurements interface {
 	GetMetricsClient() metric.Meter
+	Start()
+	ForceFlush(ctx context.Context) error
+	Shutdown(ctx context.Context) error
+	Counter(name string, opts ...measurement.InstrumentOption) (*measurement.Counter, error)
+	Histogram(name string, opts ...measurement.InstrumentOption) (*measurement.Histogram, error)
+	Timer(name string, opts ...measurement.InstrumentOption) (*measurement.Histogram, error)
+	Gauge(name string, opts ...measurement.InstrumentOption) (*measurement.Gauge, error)
+	NewModelMonitor(model modelregistry.ModelInfo, opts ...measurement.ModelMonitorOption) (*measurement.ModelMonitor, error)
 }
 
 //go:generate mockery --name predictions --output ../mocks --filename predictions_mock.go --structname PredictionsMock
@@ -113,8 +157,6 @@ type KaiSDK struct {
 	ctx context.Context
 
 	// Needed deps
-	nats           *nats.Conn
-	jetstream      nats.JetStreamContext
 	requestMessage *kai.KaiNatsMessage
 
 	// Main methods
@@ -173,8 +215,6 @@ func NewKaiSDK(logger logr.Logger, natsCli *nats.Conn, jetstreamCli nats.JetStre
 
 	sdk := KaiSDK{
 		ctx:               context.Background(),
-		nats:              natsCli,
-		jetstream:         jetstreamCli,
 		Logger:            logger,
 		Metadata:          metadata,
 		Messaging:         messagingInst,
@@ -188,6 +228,11 @@ func NewKaiSDK(logger logr.Logger, natsCli *nats.Conn, jetstreamCli nats.JetStre
 	return sdk
 }
 
+// GetContext returns the context of the request being processed, carrying its trace.
+func (sdk *KaiSDK) GetContext() context.Context {
+	return sdk.ctx
+}
+
 func (sdk *KaiSDK) GetRequestID() string {
 	if sdk.requestMessage == nil {
 		return ""
@@ -197,11 +242,57 @@ func (sdk *KaiSDK) GetRequestID() string {
 }
 
 func ShallowCopyWithRequest(sdk *KaiSDK, requestMsg *kai.KaiNatsMessage) KaiSDK {
+	return shallowCopy(sdk, sdk.ctx, requestMsg,
+		LoggerRequestID, requestMsg.GetRequestId(),
+		LoggerFromNode, requestMsg.GetFromNode(),
+		LoggerMessageType, requestMsg.GetMessageType().String(),
+	)
+}
+
+// ShallowCopyWithMsg binds the sdk to the request like ShallowCopyWithRequest, starting a span of the trace
+// propagated in the headers of the NATS message and adding its subject and delivery attempt to the logger.
+// The span is ended with tracing.EndSpan on the context of the copy once the message is processed.
+func ShallowCopyWithMsg(sdk *KaiSDK, natsMsg *nats.Msg, requestMsg *kai.KaiNatsMessage) KaiSDK {
+	ctx := tracing.StartSpan(sdk.ctx, natsMsg)
+
+	keysAndValues := []interface{}{
+		LoggerRequestID, requestMsg.GetRequestId(),
+		LoggerFromNode, requestMsg.GetFromNode(),
+		LoggerMessageType, requestMsg.GetMessageType().String(),
+		LoggerSubject, natsMsg.Subject,
+	}
+
+	// Messages processed outside of any trace leave the trace fields out.
+	if traceID, spanID := tracing.IDs(ctx); traceID != "" {
+		keysAndValues = append(keysAndValues, LoggerTraceID, traceID, LoggerSpanID, spanID)
+	}
+
+	// Only JetStream messages carry delivery metadata, plain replies do not.
+	if msgMetadata, err := natsMsg.Metadata(); err == nil {
+		keysAndValues = append(keysAndValues, LoggerDeliveryAttempt, msgMetadata.NumDelivered)
+	}
+
+	return shallowCopy(sdk, ctx, requestMsg, keysAndValues...)
+}
+
+func shallowCopy(sdk *KaiSDK, ctx context.Context, requestMsg *kai.KaiNatsMessage,
+	keysAndValues ...interface{},
+) KaiSDK {
 	hSdk := *sdk
+	hSdk.ctx = ctx
 	hSdk.requestMessage = requestMsg
-	hSdk.Logger = sdk.Logger.WithValues(LoggerRequestID, requestMsg.GetRequestId())
+	hSdk.Logger = sdk.Logger.WithValues(keysAndValues...)
 	hSdk.Predictions = prediction.NewRedisPredictionStore(requestMsg.GetRequestId())
-	hSdk.Messaging = msg.New(hSdk.Logger, sdk.nats, sdk.jetstream, requestMsg)
+
+	// Bind the messaging to the request, reusing its metrics, mocked messaging is kept as it is.
+	if messagingInst, ok := sdk.Messaging.(*msg.Messaging); ok {
+		hSdk.Messaging = messagingInst.WithRequest(hSdk.Logger, requestMsg).WithContext(ctx)
+	}
+
+	// Bind the metric helpers to the request context, mocked measurements are kept as they are.
+	if measurements, ok := sdk.Measurements.(*measurement.Measurement); ok {
+		hSdk.Measurements = measurements.WithContext(ctx)
+	}
 
 	return hSdk
 }
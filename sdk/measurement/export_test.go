@@ -0,0 +1,39 @@
+//go:build unit
+
+package measurement
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
+)
+
+func NewTestMeasurement(logger logr.Logger, metricsClient metric.Meter, meta *metadata.Metadata) *Measurement {
+	return &Measurement{
+		logger:        logger,
+		metricsClient: metricsClient,
+		metadata:      meta,
+		ctx:           context.Background(),
+		attributes: attribute.NewSet(
+			attribute.String("product", meta.GetProduct()),
+			attribute.String("version", meta.GetVersion()),
+			attribute.String("workflow", meta.GetWorkflow()),
+			attribute.String("process", meta.GetProcess()),
+		),
+		instruments: newInstrumentCache(),
+	}
+}
+
+func NewTestMeasurementWithProvider(logger logr.Logger, provider *sdkMetric.MeterProvider,
+	meta *metadata.Metadata,
+) *Measurement {
+	m := NewTestMeasurement(logger, provider.Meter("test"), meta)
+	m.provider = provider
+
+	return m
+}
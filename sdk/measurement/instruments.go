@@ -0,0 +1,218 @@
+package measurement
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type InstrumentOption func(*instrumentConfig)
+
+type instrumentConfig struct {
+	description string
+	unit        string
+	buckets     []float64
+}
+
+func WithDescription(description string) InstrumentOption {
+	return func(c *instrumentConfig) {
+		c.description = description
+	}
+}
+
+func WithUnit(unit string) InstrumentOption {
+	return func(c *instrumentConfig) {
+		c.unit = unit
+	}
+}
+
+// WithBuckets sets the explicit bucket boundaries of a histogram.
+func WithBuckets(buckets ...float64) InstrumentOption {
+	return func(c *instrumentConfig) {
+		c.buckets = buckets
+	}
+}
+
+func newInstrumentConfig(opts []InstrumentOption) instrumentConfig {
+	var config instrumentConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return config
+}
+
+// instrumentCache keeps the instruments created by name, so handlers can request them on every call.
+// It is shared by every copy of the measurements bound to a request.
+type instrumentCache struct {
+	mu         sync.Mutex
+	counters   map[string]metric.Float64Counter
+	histograms map[string]metric.Float64Histogram
+	gauges     map[string]*gaugeValues
+}
+
+func newInstrumentCache() *instrumentCache {
+	return &instrumentCache{
+		counters:   make(map[string]metric.Float64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+		gauges:     make(map[string]*gaugeValues),
+	}
+}
+
+// Counter is a monotonic counter carrying the process metadata attributes.
+type Counter struct {
+	counter metric.Float64Counter
+	ctx     context.Context
+	attrs   attribute.Set
+}
+
+func (c *Counter) Add(value float64, attrs ...attribute.KeyValue) {
+	c.counter.Add(c.ctx, value, metric.WithAttributeSet(c.attrs), metric.WithAttributes(attrs...))
+}
+
+func (c *Counter) Inc(attrs ...attribute.KeyValue) {
+	c.Add(1, attrs...)
+}
+
+// Histogram records a distribution of values carrying the process metadata attributes.
+type Histogram struct {
+	histogram metric.Float64Histogram
+	ctx       context.Context
+	attrs     attribute.Set
+}
+
+func (h *Histogram) Record(value float64, attrs ...attribute.KeyValue) {
+	h.histogram.Record(h.ctx, value, metric.WithAttributeSet(h.attrs), metric.WithAttributes(attrs...))
+}
+
+// StartTimer returns a function that records the milliseconds elapsed since the timer was started.
+func (h *Histogram) StartTimer(attrs ...attribute.KeyValue) func() {
+	start := time.Now()
+
+	return func() {
+		h.Record(float64(time.Since(start))/float64(time.Millisecond), attrs...)
+	}
+}
+
+// Gauge reports the last value set for each attribute combination.
+type Gauge struct {
+	values *gaugeValues
+	attrs  attribute.Set
+}
+
+func (g *Gauge) Set(value float64, attrs ...attribute.KeyValue) {
+	all := append(g.attrs.ToSlice(), attrs...)
+	g.values.set(attribute.NewSet(all...), value)
+}
+
+type gaugeValues struct {
+	mu     sync.Mutex
+	values map[attribute.Distinct]gaugeValue
+}
+
+type gaugeValue struct {
+	attrs attribute.Set
+	value float64
+}
+
+func (v *gaugeValues) set(attrs attribute.Set, value float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.values[attrs.Equivalent()] = gaugeValue{attrs: attrs, value: value}
+}
+
+func (v *gaugeValues) observe(_ context.Context, observer metric.Float64Observer) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, gv := range v.values {
+		observer.Observe(gv.value, metric.WithAttributeSet(gv.attrs))
+	}
+
+	return nil
+}
+
+// Counter returns the counter with the given name, creating it the first time it is requested.
+func (m Measurement) Counter(name string, opts ...InstrumentOption) (*Counter, error) {
+	m.instruments.mu.Lock()
+	defer m.instruments.mu.Unlock()
+
+	counter, ok := m.instruments.counters[name]
+	if !ok {
+		config := newInstrumentConfig(opts)
+
+		var err error
+
+		counter, err = m.metricsClient.Float64Counter(name,
+			metric.WithDescription(config.description), metric.WithUnit(config.unit))
+		if err != nil {
+			return nil, fmt.Errorf("error creating counter %s: %w", name, err)
+		}
+
+		m.instruments.counters[name] = counter
+	}
+
+	return &Counter{counter: counter, ctx: m.ctx, attrs: m.attributes}, nil
+}
+
+// Histogram returns the histogram with the given name, creating it the first time it is requested.
+func (m Measurement) Histogram(name string, opts ...InstrumentOption) (*Histogram, error) {
+	m.instruments.mu.Lock()
+	defer m.instruments.mu.Unlock()
+
+	histogram, ok := m.instruments.histograms[name]
+	if !ok {
+		config := newInstrumentConfig(opts)
+
+		histogramOpts := []metric.Float64HistogramOption{
+			metric.WithDescription(config.description), metric.WithUnit(config.unit),
+		}
+		if len(config.buckets) > 0 {
+			histogramOpts = append(histogramOpts, metric.WithExplicitBucketBoundaries(config.buckets...))
+		}
+
+		var err error
+
+		histogram, err = m.metricsClient.Float64Histogram(name, histogramOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating histogram %s: %w", name, err)
+		}
+
+		m.instruments.histograms[name] = histogram
+	}
+
+	return &Histogram{histogram: histogram, ctx: m.ctx, attrs: m.attributes}, nil
+}
+
+// Timer returns a histogram in milliseconds with the given name, meant to be used with StartTimer.
+func (m Measurement) Timer(name string, opts ...InstrumentOption) (*Histogram, error) {
+	return m.Histogram(name, append([]InstrumentOption{WithUnit("ms")}, opts...)...)
+}
+
+// Gauge returns the gauge with the given name, creating it the first time it is requested.
+func (m Measurement) Gauge(name string, opts ...InstrumentOption) (*Gauge, error) {
+	m.instruments.mu.Lock()
+	defer m.instruments.mu.Unlock()
+
+	values, ok := m.instruments.gauges[name]
+	if !ok {
+		config := newInstrumentConfig(opts)
+		values = &gaugeValues{values: make(map[attribute.Distinct]gaugeValue)}
+
+		_, err := m.metricsClient.Float64ObservableGauge(name,
+			metric.WithDescription(config.description), metric.WithUnit(config.unit),
+			metric.WithFloat64Callback(values.observe))
+		if err != nil {
+			return nil, fmt.Errorf("error creating gauge %s: %w", name, err)
+		}
+
+		m.instruments.gauges[name] = values
+	}
+
+	return &Gauge{values: values, attrs: m.attributes}, nil
+}
@@ -0,0 +1,139 @@
+//go:build unit
+
+package measurement_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/sdk/measurement"
+	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
+)
+
+type InstrumentsTestSuite struct {
+	suite.Suite
+	logger      logr.Logger
+	reader      *sdkMetric.ManualReader
+	measurement *measurement.Measurement
+}
+
+func (s *InstrumentsTestSuite) SetupSuite() {
+	s.logger = testr.NewWithOptions(s.T(), testr.Options{Verbosity: 1})
+}
+
+func (s *InstrumentsTestSuite) SetupTest() {
+	viper.Reset()
+	viper.Set(common.ConfigMetadataProductIDKey, "product-name")
+	viper.Set(common.ConfigMetadataVersionIDKey, "version-name")
+	viper.Set(common.ConfigMetadataWorkflowIDKey, "workflow-name")
+	viper.Set(common.ConfigMetadataProcessIDKey, "process-name")
+
+	s.reader = sdkMetric.NewManualReader()
+	provider := sdkMetric.NewMeterProvider(sdkMetric.WithReader(s.reader))
+
+	s.measurement = measurement.NewTestMeasurement(s.logger, provider.Meter("test"), metadata.New())
+}
+
+func (s *InstrumentsTestSuite) collect(name string) metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+
+	s.Require().NoError(s.reader.Collect(context.Background(), &data))
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+
+	s.FailNow("metric not found", name)
+
+	return nil
+}
+
+func (s *InstrumentsTestSuite) TestCounter_ExpectMetadataAttributes() {
+	// Given
+	counter, err := s.measurement.Counter("predictions", measurement.WithDescription("Number of predictions"))
+	s.Require().NoError(err)
+
+	// When
+	counter.Inc(attribute.String("class", "cat"))
+	counter.Add(2, attribute.String("class", "cat"))
+
+	// Then
+	sum, ok := s.collect("predictions").(metricdata.Sum[float64])
+	s.Require().True(ok)
+	s.Require().Len(sum.DataPoints, 1)
+	s.Equal(float64(3), sum.DataPoints[0].Value)
+
+	process, _ := sum.DataPoints[0].Attributes.Value("process")
+	class, _ := sum.DataPoints[0].Attributes.Value("class")
+	s.Equal("process-name", process.AsString())
+	s.Equal("cat", class.AsString())
+}
+
+func (s *InstrumentsTestSuite) TestCounter_SameName_ExpectCachedInstrument() {
+	// Given
+	first, err := s.measurement.Counter("predictions")
+	s.Require().NoError(err)
+
+	second, err := s.measurement.WithContext(context.Background()).Counter("predictions")
+	s.Require().NoError(err)
+
+	// When
+	first.Inc()
+	second.Inc()
+
+	// Then
+	sum, ok := s.collect("predictions").(metricdata.Sum[float64])
+	s.Require().True(ok)
+	s.Require().Len(sum.DataPoints, 1)
+	s.Equal(float64(2), sum.DataPoints[0].Value)
+}
+
+func (s *InstrumentsTestSuite) TestTimer_ExpectRecordedInMilliseconds() {
+	// Given
+	timer, err := s.measurement.Timer("inference-time", measurement.WithBuckets(1, 10, 100))
+	s.Require().NoError(err)
+
+	// When
+	stop := timer.StartTimer()
+	stop()
+
+	// Then
+	histogram, ok := s.collect("inference-time").(metricdata.Histogram[float64])
+	s.Require().True(ok)
+	s.Require().Len(histogram.DataPoints, 1)
+	s.Equal(uint64(1), histogram.DataPoints[0].Count)
+	s.Equal([]float64{1, 10, 100}, histogram.DataPoints[0].Bounds)
+}
+
+func (s *InstrumentsTestSuite) TestGauge_ExpectLastValue() {
+	// Given
+	gauge, err := s.measurement.Gauge("queue-size")
+	s.Require().NoError(err)
+
+	// When
+	gauge.Set(5)
+	gauge.Set(3)
+
+	// Then
+	lastValue, ok := s.collect("queue-size").(metricdata.Gauge[float64])
+	s.Require().True(ok)
+	s.Require().Len(lastValue.DataPoints, 1)
+	s.Equal(float64(3), lastValue.DataPoints[0].Value)
+}
+
+func TestInstrumentsTestSuite(t *testing.T) {
+	suite.Run(t, new(InstrumentsTestSuite))
+}
@@ -0,0 +1,123 @@
+//go:build unit
+
+package measurement_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/suite"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/konstellation-io/kai-gosdk/sdk/measurement"
+	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
+)
+
+// memoryExporter keeps the exported metrics in memory.
+type memoryExporter struct {
+	mu      sync.Mutex
+	exports []*metricdata.ResourceMetrics
+}
+
+func (e *memoryExporter) Temporality(kind sdkMetric.InstrumentKind) metricdata.Temporality {
+	return sdkMetric.DefaultTemporalitySelector(kind)
+}
+
+func (e *memoryExporter) Aggregation(kind sdkMetric.InstrumentKind) sdkMetric.Aggregation {
+	return sdkMetric.DefaultAggregationSelector(kind)
+}
+
+func (e *memoryExporter) Export(_ context.Context, data *metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.exports = append(e.exports, data)
+
+	return nil
+}
+
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *memoryExporter) Shutdown(context.Context) error { return nil }
+
+func (e *memoryExporter) exported() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return len(e.exports)
+}
+
+type LifecycleTestSuite struct {
+	suite.Suite
+	logger      logr.Logger
+	exporter    *memoryExporter
+	measurement *measurement.Measurement
+}
+
+func (s *LifecycleTestSuite) SetupSuite() {
+	s.logger = testr.NewWithOptions(s.T(), testr.Options{Verbosity: 1})
+}
+
+func (s *LifecycleTestSuite) SetupTest() {
+	s.exporter = &memoryExporter{}
+
+	// The interval is long enough for the metrics to be exported only when flushed.
+	reader := sdkMetric.NewPeriodicReader(s.exporter, sdkMetric.WithInterval(time.Hour))
+	provider := sdkMetric.NewMeterProvider(sdkMetric.WithReader(reader))
+
+	s.measurement = measurement.NewTestMeasurementWithProvider(s.logger, provider, metadata.New())
+	s.measurement.Start()
+}
+
+func (s *LifecycleTestSuite) recordMetric() {
+	counter, err := s.measurement.Counter("lifecycle-counter")
+	s.Require().NoError(err)
+
+	counter.Inc()
+}
+
+func (s *LifecycleTestSuite) TestForceFlush_ExpectMetricsExported() {
+	// Given
+	s.recordMetric()
+
+	// When
+	err := s.measurement.ForceFlush(context.Background())
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(1, s.exporter.exported())
+}
+
+func (s *LifecycleTestSuite) TestShutdown_ExpectPendingMetricsExported() {
+	// Given
+	s.recordMetric()
+
+	// When
+	err := s.measurement.Shutdown(context.Background())
+
+	// Then
+	s.Require().NoError(err)
+	s.Equal(1, s.exporter.exported())
+	s.Error(s.measurement.ForceFlush(context.Background()))
+}
+
+func (s *LifecycleTestSuite) TestLifecycle_WithoutProvider_ExpectNoError() {
+	// Given
+	m := measurement.NewTestMeasurement(s.logger, nil, metadata.New())
+
+	// When
+	m.Start()
+
+	// Then
+	s.NoError(m.ForceFlush(context.Background()))
+	s.NoError(m.Shutdown(context.Background()))
+}
+
+func TestLifecycleTestSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleTestSuite))
+}
@@ -1,6 +1,7 @@
 package measurement
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,41 +9,79 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
 	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/metric"
+	sdkLog "go.opentelemetry.io/otel/sdk/log"
 	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 const (
 	_persistentStorageLoggerName = "[MEASUREMENTS]"
 )
 
+var ErrUnknownExporter = errors.New("unknown measurements exporter")
+
 type Measurement struct {
 	logger        logr.Logger
 	metricsClient metric.Meter
 	metadata      *metadata.Metadata
+	ctx           context.Context
+	attributes    attribute.Set
+	instruments   *instrumentCache
+	provider      *sdkMetric.MeterProvider
+	logProvider   *sdkLog.LoggerProvider
+	traceProvider *sdkTrace.TracerProvider
 }
 
 func New(logger logr.Logger, meta *metadata.Metadata) (*Measurement, error) {
-	endpoint := viper.GetString(common.ConfigMeasurementsEndpointKey)
-	insecure := viper.GetBool(common.ConfigMeasurementsInsecureKey)
-	timeout := viper.GetInt(common.ConfigMeasurementsTimeoutKey)
-	interval := viper.GetInt(common.ConfigMeasurementsMetricsIntervalKey)
+	provider, err := initMetrics(logger, viper.GetString(common.ConfigMeasurementsExporterKey), meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var logProvider *sdkLog.LoggerProvider
 
-	metricsClient, err := initMetrics(logger, endpoint, insecure, timeout, interval, meta)
+	if viper.GetBool(common.ConfigMeasurementsLogsEnabledKey) {
+		logProvider, err = initLogs(logger, meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	traceProvider, err := initTraces(logger, meta, viper.GetBool(common.ConfigMeasurementsTracesEnabledKey))
 	if err != nil {
 		return nil, err
 	}
 
 	return &Measurement{
 		logger:        logger,
-		metricsClient: metricsClient,
+		metricsClient: provider.Meter(metrics.MeterName),
 		metadata:      meta,
+		ctx:           context.Background(),
+		attributes: attribute.NewSet(
+			attribute.String("product", meta.GetProduct()),
+			attribute.String("version", meta.GetVersion()),
+			attribute.String("workflow", meta.GetWorkflow()),
+			attribute.String("process", meta.GetProcess()),
+		),
+		instruments:   newInstrumentCache(),
+		provider:      provider,
+		logProvider:   logProvider,
+		traceProvider: traceProvider,
 	}, nil
 }
 
@@ -50,24 +89,169 @@ func (m Measurement) GetMetricsClient() metric.Meter {
 	return m.metricsClient
 }
 
-func initMetrics(logger logr.Logger, endpoint string, insecure bool, timeout, interval int, meta *metadata.Metadata) (metric.Meter, error) {
+// WithContext returns a copy of the measurements whose instruments record with the given context,
+// sharing the instruments already created.
+func (m Measurement) WithContext(ctx context.Context) *Measurement {
+	m.ctx = ctx
+	return &m
+}
+
+// Start registers the meter and tracer providers as the global ones, so the metrics and spans recorded by the
+// runners are exported too, and the logger provider if logs are exported.
+func (m Measurement) Start() {
+	if m.provider == nil {
+		return
+	}
+
+	otel.SetMeterProvider(m.provider)
+
+	if m.traceProvider != nil {
+		otel.SetTracerProvider(m.traceProvider)
+	}
+
+	if m.logProvider != nil {
+		global.SetLoggerProvider(m.logProvider)
+	}
+
+	m.logger.WithName(_persistentStorageLoggerName).V(1).Info("Measurements started")
+}
+
+// ForceFlush exports the metrics recorded so far without waiting for the next export interval.
+func (m Measurement) ForceFlush(ctx context.Context) error {
+	if m.provider == nil {
+		return nil
+	}
+
+	err := m.provider.ForceFlush(ctx)
+	if err != nil {
+		return fmt.Errorf("error flushing metrics: %w", err)
+	}
+
+	if m.logProvider != nil {
+		err = m.logProvider.ForceFlush(ctx)
+		if err != nil {
+			return fmt.Errorf("error flushing logs: %w", err)
+		}
+	}
+
+	if m.traceProvider != nil {
+		err = m.traceProvider.ForceFlush(ctx)
+		if err != nil {
+			return fmt.Errorf("error flushing traces: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Shutdown exports the pending metrics and stops the meter provider, metrics recorded later on are dropped.
+func (m Measurement) Shutdown(ctx context.Context) error {
+	if m.provider == nil {
+		return nil
+	}
+
+	err := m.provider.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("error shutting down metrics: %w", err)
+	}
+
+	if m.logProvider != nil {
+		err = m.logProvider.Shutdown(ctx)
+		if err != nil {
+			return fmt.Errorf("error shutting down logs: %w", err)
+		}
+	}
+
+	if m.traceProvider != nil {
+		err = m.traceProvider.Shutdown(ctx)
+		if err != nil {
+			return fmt.Errorf("error shutting down traces: %w", err)
+		}
+	}
+
+	m.logger.WithName(_persistentStorageLoggerName).V(1).Info("Measurements shut down")
+
+	return nil
+}
+
+func initMetrics(logger logr.Logger, exporter string, meta *metadata.Metadata) (*sdkMetric.MeterProvider, error) {
 	res, err := initResource(meta)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing metrics: %w", err)
 	}
 
-	exporter, err := initExporter(endpoint, insecure, timeout)
+	reader, err := initReader(exporter)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing metrics: %w", err)
 	}
 
-	provider := initProvider(exporter, res, interval)
+	provider := initProvider(reader, res)
+
+	logger.WithName(_persistentStorageLoggerName).Info(fmt.Sprintf("Successfully initialized metrics with %s exporter", exporter))
+
+	return provider, nil
+}
+
+// initLogs exports the logs to the OpenTelemetry collector of the metrics over gRPC.
+func initLogs(logger logr.Logger, meta *metadata.Metadata) (*sdkLog.LoggerProvider, error) {
+	res, err := initResource(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing logs: %w", err)
+	}
+
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(viper.GetString(common.ConfigMeasurementsEndpointKey)),
+		otlploggrpc.WithTimeout(time.Duration(viper.GetInt(common.ConfigMeasurementsTimeoutKey)) * time.Second),
+	}
 
-	otel.SetMeterProvider(provider)
+	if viper.GetBool(common.ConfigMeasurementsInsecureKey) {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+
+	exporter, err := otlploggrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing logs: %w", err)
+	}
 
-	logger.WithName(_persistentStorageLoggerName).Info("Successfully initialized metrics")
+	logger.WithName(_persistentStorageLoggerName).Info("Successfully initialized logs exporter")
 
-	return provider.Meter("measurements"), nil
+	return sdkLog.NewLoggerProvider(
+		sdkLog.WithResource(res),
+		sdkLog.WithProcessor(sdkLog.NewBatchProcessor(exporter)),
+	), nil
+}
+
+// initTraces records the spans of the processed messages, so the logs carry the ids of their trace. The spans are
+// only exported over OTLP gRPC to the collector of the metrics when enabled.
+func initTraces(logger logr.Logger, meta *metadata.Metadata, export bool) (*sdkTrace.TracerProvider, error) {
+	res, err := initResource(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing traces: %w", err)
+	}
+
+	opts := []sdkTrace.TracerProviderOption{sdkTrace.WithResource(res)}
+
+	if export {
+		exporterOpts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(viper.GetString(common.ConfigMeasurementsEndpointKey)),
+			otlptracegrpc.WithTimeout(time.Duration(viper.GetInt(common.ConfigMeasurementsTimeoutKey)) * time.Second),
+		}
+
+		if viper.GetBool(common.ConfigMeasurementsInsecureKey) {
+			exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(context.Background(), exporterOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing traces: %w", err)
+		}
+
+		opts = append(opts, sdkTrace.WithBatcher(exporter))
+
+		logger.WithName(_persistentStorageLoggerName).Info("Successfully initialized traces exporter")
+	}
+
+	return sdkTrace.NewTracerProvider(opts...), nil
 }
 
 func initResource(meta *metadata.Metadata) (*resource.Resource, error) {
@@ -82,31 +266,73 @@ func initResource(meta *metadata.Metadata) (*resource.Resource, error) {
 	)
 }
 
-func initExporter(endpoint string, insecure bool, timeout int) (*otlpmetricgrpc.Exporter, error) {
-	if insecure {
-		return otlpmetricgrpc.New(
-			context.Background(),
-			otlpmetricgrpc.WithEndpoint(endpoint),
-			otlpmetricgrpc.WithTimeout(time.Duration(timeout)*time.Second),
-			otlpmetricgrpc.WithInsecure(),
-		)
+// initReader returns the reader of the configured exporter, nil for the noop exporter.
+func initReader(exporter string) (sdkMetric.Reader, error) {
+	endpoint := viper.GetString(common.ConfigMeasurementsEndpointKey)
+	insecure := viper.GetBool(common.ConfigMeasurementsInsecureKey)
+	timeout := time.Duration(viper.GetInt(common.ConfigMeasurementsTimeoutKey)) * time.Second
+	interval := time.Duration(viper.GetInt(common.ConfigMeasurementsMetricsIntervalKey)) * time.Second
+
+	var (
+		metricExporter sdkMetric.Exporter
+		err            error
+	)
+
+	switch exporter {
+	case common.MeasurementsExporterOtlpGrpc:
+		metricExporter, err = initGrpcExporter(endpoint, insecure, timeout)
+	case common.MeasurementsExporterOtlpHTTP:
+		metricExporter, err = initHTTPExporter(endpoint, insecure, timeout)
+	case common.MeasurementsExporterStdout:
+		metricExporter, err = stdoutmetric.New()
+	case common.MeasurementsExporterPrometheus:
+		// Registered in the default prometheus registry, served by the runner /metrics endpoint.
+		return prometheus.New()
+	case common.MeasurementsExporterNoop:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, exporter)
 	}
 
-	return otlpmetricgrpc.New(
-		context.Background(),
+	if err != nil {
+		return nil, err
+	}
+
+	return sdkMetric.NewPeriodicReader(metricExporter, sdkMetric.WithInterval(interval)), nil
+}
+
+func initGrpcExporter(endpoint string, insecure bool, timeout time.Duration) (*otlpmetricgrpc.Exporter, error) {
+	opts := []otlpmetricgrpc.Option{
 		otlpmetricgrpc.WithEndpoint(endpoint),
-		otlpmetricgrpc.WithTimeout(time.Duration(timeout)*time.Second),
-	)
+		otlpmetricgrpc.WithTimeout(timeout),
+	}
+
+	if insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+
+	return otlpmetricgrpc.New(context.Background(), opts...)
 }
 
-func initProvider(exporter *otlpmetricgrpc.Exporter, res *resource.Resource, interval int) *sdkMetric.MeterProvider {
-	return sdkMetric.NewMeterProvider(
-		sdkMetric.WithResource(res),
-		sdkMetric.WithReader(
-			sdkMetric.NewPeriodicReader(
-				exporter,
-				sdkMetric.WithInterval(time.Duration(interval)*time.Second),
-			),
-		),
-	)
+func initHTTPExporter(endpoint string, insecure bool, timeout time.Duration) (*otlpmetrichttp.Exporter, error) {
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(endpoint),
+		otlpmetrichttp.WithTimeout(timeout),
+	}
+
+	if insecure {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	}
+
+	return otlpmetrichttp.New(context.Background(), opts...)
+}
+
+func initProvider(reader sdkMetric.Reader, res *resource.Resource) *sdkMetric.MeterProvider {
+	opts := []sdkMetric.Option{sdkMetric.WithResource(res)}
+
+	if reader != nil {
+		opts = append(opts, sdkMetric.WithReader(reader))
+	}
+
+	return sdkMetric.NewMeterProvider(opts...)
 }
@@ -3,6 +3,7 @@
 package measurement_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
@@ -11,6 +12,8 @@ import (
 	"github.com/go-logr/logr/testr"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/konstellation-io/kai-gosdk/sdk/measurement"
 	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
@@ -41,6 +44,7 @@ func (s *SdkMeasurementTestSuite) TestMetadata_GetMetadata_ExpectOK() {
 	viper.SetDefault(common.ConfigCcProductBucketKey, "product-bucket")
 	viper.SetDefault(common.ConfigCcWorkflowBucketKey, "workflow-bucket")
 	viper.SetDefault(common.ConfigCcProcessBucketKey, "process-bucket")
+	viper.SetDefault(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterOtlpGrpc)
 	viper.SetDefault(common.ConfigMeasurementsEndpointKey, "http://localhost:4137")
 	viper.SetDefault(common.ConfigMeasurementsInsecureKey, true)
 	viper.SetDefault(common.ConfigMeasurementsTimeoutKey, 10)
@@ -56,6 +60,81 @@ func (s *SdkMeasurementTestSuite) TestMetadata_GetMetadata_ExpectOK() {
 	s.Nil(err)
 }
 
+func (s *SdkMeasurementTestSuite) TestNew_WithEachExporter_ExpectOK() {
+	exporters := []string{
+		common.MeasurementsExporterOtlpGrpc,
+		common.MeasurementsExporterOtlpHTTP,
+		common.MeasurementsExporterPrometheus,
+		common.MeasurementsExporterStdout,
+		common.MeasurementsExporterNoop,
+	}
+
+	for _, exporter := range exporters {
+		s.Run(exporter, func() {
+			// Given
+			viper.Set(common.ConfigMeasurementsExporterKey, exporter)
+			viper.Set(common.ConfigMeasurementsEndpointKey, "localhost:4318")
+			viper.Set(common.ConfigMeasurementsInsecureKey, true)
+			viper.Set(common.ConfigMeasurementsTimeoutKey, 10)
+			viper.Set(common.ConfigMeasurementsMetricsIntervalKey, 10)
+
+			// When
+			sdkMeasurement, err := measurement.New(s.logger, metadata.New())
+
+			// Then
+			s.Require().NoError(err)
+			s.NotNil(sdkMeasurement.GetMetricsClient())
+		})
+	}
+}
+
+func (s *SdkMeasurementTestSuite) TestNew_WithUnknownExporter_ExpectError() {
+	// Given
+	viper.Set(common.ConfigMeasurementsExporterKey, "statsd")
+
+	// When
+	_, err := measurement.New(s.logger, metadata.New())
+
+	// Then
+	s.ErrorIs(err, measurement.ErrUnknownExporter)
+}
+
+func (s *SdkMeasurementTestSuite) TestStart_ExpectSpansRecorded() {
+	// Given
+	viper.Set(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterNoop)
+
+	sdkMeasurement, err := measurement.New(s.logger, metadata.New())
+	s.Require().NoError(err)
+
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	// When
+	sdkMeasurement.Start()
+
+	// Then
+	_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+	defer span.End()
+
+	s.True(span.SpanContext().IsValid())
+	s.Require().NoError(sdkMeasurement.Shutdown(context.Background()))
+}
+
+func (s *SdkMeasurementTestSuite) TestNew_WithTracesEnabled_ExpectOK() {
+	// Given
+	viper.Set(common.ConfigMeasurementsExporterKey, common.MeasurementsExporterNoop)
+	viper.Set(common.ConfigMeasurementsTracesEnabledKey, true)
+	viper.Set(common.ConfigMeasurementsEndpointKey, "localhost:4317")
+	viper.Set(common.ConfigMeasurementsInsecureKey, true)
+	viper.Set(common.ConfigMeasurementsTimeoutKey, 10)
+
+	// When
+	sdkMeasurement, err := measurement.New(s.logger, metadata.New())
+
+	// Then
+	s.Require().NoError(err)
+	s.NotNil(sdkMeasurement)
+}
+
 func TestSdkMetadataTestSuite(t *testing.T) {
 	suite.Run(t, new(SdkMeasurementTestSuite))
 }
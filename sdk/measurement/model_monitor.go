@@ -0,0 +1,328 @@
+package measurement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	modelregistry "github.com/konstellation-io/kai-gosdk/sdk/model-registry"
+	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
+)
+
+var (
+	ErrInvalidWindowSize     = errors.New("the window size must be greater than zero")
+	ErrEmptyReference        = errors.New("the reference distribution is empty")
+	ErrUndefinedModelName    = errors.New("the model name is mandatory")
+	ErrInvalidReferenceValue = errors.New("the reference distribution cannot contain negative values")
+)
+
+const (
+	_defaultWindowSize = 1000
+	// _distributionEpsilon replaces empty classes so drift metrics stay finite.
+	_distributionEpsilon = 1e-4
+)
+
+// ReferenceStorage is where reference distributions are kept, usually the persistent storage of the sdk.
+type ReferenceStorage interface {
+	Save(key string, value []byte, ttlDays ...int) (*persistentstorage.ObjectInfo, error)
+	Get(key string, version ...string) (*persistentstorage.Object, error)
+}
+
+// Distribution maps each class to its share of the predictions.
+type Distribution map[string]float64
+
+// Prediction is a prediction event of a model.
+type Prediction struct {
+	Class      string
+	Confidence float64
+	Latency    time.Duration
+}
+
+type ModelMonitorOption func(*ModelMonitor)
+
+// WithWindowSize sets the number of latest predictions used to compute the rolling statistics.
+func WithWindowSize(size int) ModelMonitorOption {
+	return func(mm *ModelMonitor) {
+		mm.windowSize = size
+	}
+}
+
+// WithReference sets the distribution the drift is computed against.
+func WithReference(reference Distribution) ModelMonitorOption {
+	return func(mm *ModelMonitor) {
+		mm.reference = reference
+	}
+}
+
+// ModelMonitor records the prediction events of a model and computes the class distribution of the latest
+// predictions and its drift against a reference distribution, exporting them as metrics.
+type ModelMonitor struct {
+	mu           sync.Mutex
+	attrs        []attribute.KeyValue
+	windowSize   int
+	window       []string
+	next         int
+	counts       map[string]int
+	reference    Distribution
+	predictions  *Counter
+	confidence   *Histogram
+	latency      *Histogram
+	distribution *Gauge
+	psi          *Gauge
+	kl           *Gauge
+}
+
+// NewModelMonitor creates the monitor of the given model, usually retrieved from the model registry.
+func (m Measurement) NewModelMonitor(model modelregistry.ModelInfo, opts ...ModelMonitorOption) (*ModelMonitor, error) {
+	if model.Name == "" {
+		return nil, ErrUndefinedModelName
+	}
+
+	mm := &ModelMonitor{
+		attrs: []attribute.KeyValue{
+			attribute.String("model", model.Name),
+			attribute.String("model_version", model.Version),
+		},
+		windowSize: _defaultWindowSize,
+		counts:     make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(mm)
+	}
+
+	if mm.windowSize <= 0 {
+		return nil, ErrInvalidWindowSize
+	}
+
+	if mm.reference != nil {
+		err := mm.reference.validate()
+		if err != nil {
+			return nil, err
+		}
+
+		mm.reference = mm.reference.normalize()
+	}
+
+	mm.window = make([]string, 0, mm.windowSize)
+
+	err := mm.initInstruments(m)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing model monitor: %w", err)
+	}
+
+	return mm, nil
+}
+
+func (mm *ModelMonitor) initInstruments(m Measurement) error {
+	var errs [6]error
+
+	mm.predictions, errs[0] = m.Counter("model-predictions",
+		WithDescription("Number of predictions by model and predicted class."))
+	mm.confidence, errs[1] = m.Histogram("model-prediction-confidence",
+		WithDescription("Confidence of the predictions by model and predicted class."),
+		WithBuckets(0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 0.95, 0.99))
+	mm.latency, errs[2] = m.Timer("model-prediction-latency",
+		WithDescription("How long it takes the model to predict."))
+	mm.distribution, errs[3] = m.Gauge("model-class-distribution",
+		WithDescription("Share of each class in the latest predictions."))
+	mm.psi, errs[4] = m.Gauge("model-drift-psi",
+		WithDescription("Population stability index of the latest predictions against the reference distribution."))
+	mm.kl, errs[5] = m.Gauge("model-drift-kl",
+		WithDescription("Kullback-Leibler divergence of the latest predictions from the reference distribution."))
+
+	return errors.Join(errs[:]...)
+}
+
+// RecordPrediction records a prediction event and updates the rolling statistics.
+func (mm *ModelMonitor) RecordPrediction(prediction Prediction) {
+	classAttrs := append(mm.getAttributes(), attribute.String("class", prediction.Class))
+
+	mm.predictions.Inc(classAttrs...)
+	mm.confidence.Record(prediction.Confidence, classAttrs...)
+
+	if prediction.Latency > 0 {
+		mm.latency.Record(float64(prediction.Latency)/float64(time.Millisecond), mm.getAttributes()...)
+	}
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.addToWindow(prediction.Class)
+	mm.exportStatistics()
+}
+
+// Distribution returns the class distribution of the latest predictions.
+func (mm *ModelMonitor) Distribution() Distribution {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	return mm.getDistribution()
+}
+
+// Drift returns the population stability index and the Kullback-Leibler divergence of the latest predictions
+// against the reference distribution.
+func (mm *ModelMonitor) Drift() (psi, kl float64, err error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	if len(mm.reference) == 0 {
+		return 0, 0, ErrEmptyReference
+	}
+
+	psi, kl = computeDrift(mm.getDistribution(), mm.reference)
+
+	return psi, kl, nil
+}
+
+// SetReference replaces the distribution the drift is computed against.
+func (mm *ModelMonitor) SetReference(reference Distribution) error {
+	err := reference.validate()
+	if err != nil {
+		return err
+	}
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.reference = reference.normalize()
+	mm.exportStatistics()
+
+	return nil
+}
+
+// LoadReference sets as reference the distribution stored as JSON in the given key.
+func (mm *ModelMonitor) LoadReference(storage ReferenceStorage, key string, version ...string) error {
+	object, err := storage.Get(key, version...)
+	if err != nil {
+		return fmt.Errorf("error loading reference distribution: %w", err)
+	}
+
+	var reference Distribution
+
+	err = json.Unmarshal(object.GetBytes(), &reference)
+	if err != nil {
+		return fmt.Errorf("error parsing reference distribution: %w", err)
+	}
+
+	return mm.SetReference(reference)
+}
+
+// SaveReference stores as JSON in the given key the class distribution of the latest predictions,
+// so it can be loaded as reference later on.
+func (mm *ModelMonitor) SaveReference(storage ReferenceStorage, key string) error {
+	reference, err := json.Marshal(mm.Distribution())
+	if err != nil {
+		return fmt.Errorf("error serializing reference distribution: %w", err)
+	}
+
+	_, err = storage.Save(key, reference)
+	if err != nil {
+		return fmt.Errorf("error saving reference distribution: %w", err)
+	}
+
+	return nil
+}
+
+func (mm *ModelMonitor) getAttributes() []attribute.KeyValue {
+	return append([]attribute.KeyValue{}, mm.attrs...)
+}
+
+func (mm *ModelMonitor) addToWindow(class string) {
+	if len(mm.window) < mm.windowSize {
+		mm.window = append(mm.window, class)
+	} else {
+		mm.counts[mm.window[mm.next]]--
+		mm.window[mm.next] = class
+		mm.next = (mm.next + 1) % mm.windowSize
+	}
+
+	mm.counts[class]++
+}
+
+func (mm *ModelMonitor) getDistribution() Distribution {
+	distribution := make(Distribution, len(mm.counts))
+
+	for class, count := range mm.counts {
+		if len(mm.window) > 0 {
+			distribution[class] = float64(count) / float64(len(mm.window))
+		}
+	}
+
+	return distribution
+}
+
+func (mm *ModelMonitor) exportStatistics() {
+	distribution := mm.getDistribution()
+
+	for class, share := range distribution {
+		mm.distribution.Set(share, append(mm.getAttributes(), attribute.String("class", class))...)
+	}
+
+	if len(mm.reference) == 0 || len(distribution) == 0 {
+		return
+	}
+
+	psi, kl := computeDrift(distribution, mm.reference)
+
+	mm.psi.Set(psi, mm.getAttributes()...)
+	mm.kl.Set(kl, mm.getAttributes()...)
+}
+
+func (d Distribution) validate() error {
+	if len(d) == 0 {
+		return ErrEmptyReference
+	}
+
+	for _, value := range d {
+		if value < 0 {
+			return ErrInvalidReferenceValue
+		}
+	}
+
+	return nil
+}
+
+// normalize returns a copy of the distribution whose values sum one, so counts can be used as reference too.
+func (d Distribution) normalize() Distribution {
+	var total float64
+	for _, value := range d {
+		total += value
+	}
+
+	normalized := make(Distribution, len(d))
+
+	for class, value := range d {
+		if total > 0 {
+			normalized[class] = value / total
+		}
+	}
+
+	return normalized
+}
+
+func computeDrift(actual, reference Distribution) (psi, kl float64) {
+	classes := make(map[string]struct{}, len(reference))
+	for class := range reference {
+		classes[class] = struct{}{}
+	}
+
+	for class := range actual {
+		classes[class] = struct{}{}
+	}
+
+	for class := range classes {
+		a := math.Max(actual[class], _distributionEpsilon)
+		e := math.Max(reference[class], _distributionEpsilon)
+
+		psi += (a - e) * math.Log(a/e)
+		kl += a * math.Log(a/e)
+	}
+
+	return psi, kl
+}
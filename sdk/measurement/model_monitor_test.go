@@ -0,0 +1,193 @@
+//go:build unit
+
+package measurement_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/suite"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/konstellation-io/kai-gosdk/mocks"
+	"github.com/konstellation-io/kai-gosdk/sdk/measurement"
+	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
+	modelregistry "github.com/konstellation-io/kai-gosdk/sdk/model-registry"
+	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
+)
+
+type ModelMonitorTestSuite struct {
+	suite.Suite
+	logger      logr.Logger
+	reader      *sdkMetric.ManualReader
+	measurement *measurement.Measurement
+	storage     *mocks.PersistentStorageMock
+	model       modelregistry.ModelInfo
+}
+
+func (s *ModelMonitorTestSuite) SetupSuite() {
+	s.logger = testr.NewWithOptions(s.T(), testr.Options{Verbosity: 1})
+	s.model = modelregistry.ModelInfo{Name: "classifier", Version: "v1.0.0"}
+}
+
+func (s *ModelMonitorTestSuite) SetupTest() {
+	s.reader = sdkMetric.NewManualReader()
+	provider := sdkMetric.NewMeterProvider(sdkMetric.WithReader(s.reader))
+
+	s.measurement = measurement.NewTestMeasurement(s.logger, provider.Meter("test"), metadata.New())
+	s.storage = mocks.NewPersistentStorageMock(s.T())
+}
+
+func (s *ModelMonitorTestSuite) collect() map[string]metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+
+	s.Require().NoError(s.reader.Collect(context.Background(), &data))
+
+	collected := make(map[string]metricdata.Aggregation)
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			collected[m.Name] = m.Data
+		}
+	}
+
+	return collected
+}
+
+func (s *ModelMonitorTestSuite) TestNewModelMonitor_WithInvalidOptions_ExpectError() {
+	_, err := s.measurement.NewModelMonitor(modelregistry.ModelInfo{})
+	s.ErrorIs(err, measurement.ErrUndefinedModelName)
+
+	_, err = s.measurement.NewModelMonitor(s.model, measurement.WithWindowSize(0))
+	s.ErrorIs(err, measurement.ErrInvalidWindowSize)
+
+	_, err = s.measurement.NewModelMonitor(s.model, measurement.WithReference(measurement.Distribution{"cat": -1}))
+	s.ErrorIs(err, measurement.ErrInvalidReferenceValue)
+}
+
+func (s *ModelMonitorTestSuite) TestRecordPrediction_ExpectRollingDistribution() {
+	// Given
+	monitor, err := s.measurement.NewModelMonitor(s.model, measurement.WithWindowSize(4))
+	s.Require().NoError(err)
+
+	// When
+	for _, class := range []string{"cat", "cat", "dog", "dog", "bird", "bird"} {
+		monitor.RecordPrediction(measurement.Prediction{Class: class, Confidence: 0.9, Latency: time.Millisecond})
+	}
+
+	// Then
+	distribution := monitor.Distribution()
+	s.InDelta(0.5, distribution["dog"], 1e-9)
+	s.InDelta(0.5, distribution["bird"], 1e-9)
+	s.InDelta(0, distribution["cat"], 1e-9)
+
+	collected := s.collect()
+	s.Contains(collected, "model-predictions")
+	s.Contains(collected, "model-prediction-confidence")
+	s.Contains(collected, "model-prediction-latency")
+	s.Contains(collected, "model-class-distribution")
+	s.NotContains(collected, "model-drift-psi")
+}
+
+func (s *ModelMonitorTestSuite) TestDrift_ExpectPSIAndKL() {
+	// Given
+	monitor, err := s.measurement.NewModelMonitor(s.model,
+		measurement.WithReference(measurement.Distribution{"cat": 50, "dog": 50}))
+	s.Require().NoError(err)
+
+	_, _, err = monitor.Drift()
+	s.Require().NoError(err)
+
+	// When
+	for _, class := range []string{"cat", "cat", "cat", "dog"} {
+		monitor.RecordPrediction(measurement.Prediction{Class: class, Confidence: 0.8})
+	}
+
+	psi, kl, err := monitor.Drift()
+
+	// Then
+	s.Require().NoError(err)
+	s.InDelta(0.2747, psi, 1e-4)
+	s.InDelta(0.1308, kl, 1e-4)
+
+	psiGauge, ok := s.collect()["model-drift-psi"].(metricdata.Gauge[float64])
+	s.Require().True(ok)
+	s.Require().Len(psiGauge.DataPoints, 1)
+	s.InDelta(psi, psiGauge.DataPoints[0].Value, 1e-9)
+}
+
+func (s *ModelMonitorTestSuite) TestDrift_WithoutReference_ExpectError() {
+	// Given
+	monitor, err := s.measurement.NewModelMonitor(s.model)
+	s.Require().NoError(err)
+
+	// When
+	_, _, err = monitor.Drift()
+
+	// Then
+	s.ErrorIs(err, measurement.ErrEmptyReference)
+}
+
+func (s *ModelMonitorTestSuite) TestSetReference_WithEqualDistribution_ExpectNoDrift() {
+	// Given
+	monitor, err := s.measurement.NewModelMonitor(s.model)
+	s.Require().NoError(err)
+
+	monitor.RecordPrediction(measurement.Prediction{Class: "cat"})
+	monitor.RecordPrediction(measurement.Prediction{Class: "dog"})
+
+	// When
+	err = monitor.SetReference(measurement.Distribution{"cat": 0.5, "dog": 0.5})
+
+	// Then
+	s.Require().NoError(err)
+
+	psi, kl, err := monitor.Drift()
+	s.Require().NoError(err)
+	s.InDelta(0, psi, 1e-9)
+	s.InDelta(0, kl, 1e-9)
+}
+
+func (s *ModelMonitorTestSuite) TestLoadReference_WhenStorageFails_ExpectError() {
+	// Given
+	monitor, err := s.measurement.NewModelMonitor(s.model)
+	s.Require().NoError(err)
+
+	s.storage.On("Get", "reference.json").Return(nil, errors.New("not found"))
+
+	// When
+	err = monitor.LoadReference(s.storage, "reference.json")
+
+	// Then
+	s.Error(err)
+}
+
+func (s *ModelMonitorTestSuite) TestSaveReference_ExpectCurrentDistribution() {
+	// Given
+	monitor, err := s.measurement.NewModelMonitor(s.model)
+	s.Require().NoError(err)
+
+	monitor.RecordPrediction(measurement.Prediction{Class: "cat"})
+
+	expected, err := json.Marshal(measurement.Distribution{"cat": 1})
+	s.Require().NoError(err)
+
+	s.storage.On("Save", "reference.json", expected).Return(&persistentstorage.ObjectInfo{}, nil)
+
+	// When
+	err = monitor.SaveReference(s.storage, "reference.json")
+
+	// Then
+	s.Require().NoError(err)
+	s.storage.AssertCalled(s.T(), "Save", "reference.json", expected)
+}
+
+func TestModelMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(ModelMonitorTestSuite))
+}
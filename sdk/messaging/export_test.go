@@ -3,8 +3,12 @@
 package messaging
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
 	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
 	"github.com/nats-io/nats.go"
 )
 
@@ -17,5 +21,17 @@ func NewTestMessaging(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContex
 		js,
 		requestMessage,
 		messagingUtils,
+		"",
+		nil,
+		metadata.New(),
+		context.Background(),
 	}
 }
+
+func (ms *Messaging) GetMetrics() *metrics.Runner {
+	return ms.metrics
+}
+
+func (ms *Messaging) GetRequestMessage() *kai.KaiNatsMessage {
+	return ms.requestMessage
+}
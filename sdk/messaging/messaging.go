@@ -1,9 +1,14 @@
 package messaging
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
 	kai "github.com/konstellation-io/kai-gosdk/protos"
+	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
@@ -16,6 +21,10 @@ type Messaging struct {
 	jetstream      nats.JetStreamContext
 	requestMessage *kai.KaiNatsMessage
 	messagingUtils messagingUtils
+	replySubject   string
+	metrics        *metrics.Runner
+	metadata       *metadata.Metadata
+	ctx            context.Context
 }
 
 func New(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContext,
@@ -27,7 +36,43 @@ func New(logger logr.Logger, ns *nats.Conn, js nats.JetStreamContext,
 		js,
 		requestMessage,
 		NewMessagingUtils(ns, js),
+		"",
+		newMetrics(logger),
+		metadata.New(),
+		context.Background(),
+	}
+}
+
+// newMetrics uses the global meter provider, set up by the measurements component.
+func newMetrics(logger logr.Logger) *metrics.Runner {
+	runnerMetrics, err := metrics.NewRunner(otel.Meter(metrics.MeterName))
+	if err != nil {
+		logger.WithName(_messagingLoggerName).Error(err, "Error initializing messaging metrics")
+		return nil
 	}
+
+	return runnerMetrics
+}
+
+// WithRequest returns a copy bound to the request being processed, sharing the metrics so they are only created once.
+func (ms *Messaging) WithRequest(logger logr.Logger, requestMessage *kai.KaiNatsMessage) *Messaging {
+	requestMessaging := *ms
+	requestMessaging.logger = logger
+	requestMessaging.requestMessage = requestMessage
+
+	return &requestMessaging
+}
+
+// WithReplySubject sets the subject where the responses to the messages sent by this instance must be routed to.
+func (ms *Messaging) WithReplySubject(replySubject string) *Messaging {
+	ms.replySubject = replySubject
+	return ms
+}
+
+// WithContext sets the context of the request being processed, whose trace is propagated to the messages sent.
+func (ms *Messaging) WithContext(ctx context.Context) *Messaging {
+	ms.ctx = ctx
+	return ms
 }
 
 func (ms Messaging) SendOutput(response proto.Message, channelOpt ...string) error {
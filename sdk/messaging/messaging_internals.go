@@ -1,7 +1,9 @@
 package messaging
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/konstellation-io/kai-gosdk/internal/errors"
 	"github.com/nats-io/nats.go"
@@ -12,6 +14,8 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/metrics"
+	"github.com/konstellation-io/kai-gosdk/internal/tracing"
 	kai "github.com/konstellation-io/kai-gosdk/protos"
 )
 
@@ -55,10 +59,11 @@ func (ms Messaging) publishAny(payload *anypb.Any, requestID string, msgType kai
 
 func (ms Messaging) publishError(requestID, errMsg, channel string) {
 	responseMsg := &kai.KaiNatsMessage{
-		RequestId:   requestID,
-		Error:       errMsg,
-		FromNode:    viper.GetString(common.ConfigMetadataProcessIDKey),
-		MessageType: kai.MessageType_ERROR,
+		RequestId:    requestID,
+		Error:        errMsg,
+		FromNode:     viper.GetString(common.ConfigMetadataProcessIDKey),
+		MessageType:  kai.MessageType_ERROR,
+		ReplySubject: ms.getReplySubject(),
 	}
 	ms.publishResponse(responseMsg, channel)
 }
@@ -70,14 +75,33 @@ func (ms Messaging) newResponseMsg(payload *anypb.Any, requestID string,
 		"request id %s and message type %s", requestID, msgType))
 
 	return &kai.KaiNatsMessage{
-		RequestId:   requestID,
-		Payload:     payload,
-		FromNode:    viper.GetString(common.ConfigMetadataProcessIDKey),
-		MessageType: msgType,
+		RequestId:    requestID,
+		Payload:      payload,
+		FromNode:     viper.GetString(common.ConfigMetadataProcessIDKey),
+		MessageType:  msgType,
+		ReplySubject: ms.getReplySubject(),
 	}
 }
 
+// getReplySubject returns the reply subject set for this instance or, if undefined,
+// propagates the one received in the request message.
+func (ms Messaging) getReplySubject() string {
+	if ms.replySubject != "" {
+		return ms.replySubject
+	}
+
+	return ms.requestMessage.GetReplySubject()
+}
+
 func (ms Messaging) publishResponse(responseMsg *kai.KaiNatsMessage, channel string) {
+	// Exit processes have no output subject, the responses without a reply subject can't be routed anywhere.
+	if ms.isExitProcess() && responseMsg.GetReplySubject() == "" {
+		ms.logger.WithName(_messagingLoggerName).Error(errors.ErrMissingReplySubject,
+			fmt.Sprintf("Discarding response for request id %s", responseMsg.GetRequestId()))
+
+		return
+	}
+
 	outputSubject := ms.getOutputSubject(channel)
 
 	outputMsg, err := proto.Marshal(responseMsg)
@@ -97,10 +121,18 @@ func (ms Messaging) publishResponse(responseMsg *kai.KaiNatsMessage, channel str
 		return
 	}
 
+	defer ms.recordPublished(time.Now(), outputSubject, responseMsg, outputMsg)
+
+	// Exit processes route their outputs straight back to the trigger replica waiting for them.
+	if ms.isExitProcess() {
+		ms.publishReply(responseMsg.GetReplySubject(), outputMsg, responseMsg.GetRequestId())
+		return
+	}
+
 	ms.logger.WithName(_messagingLoggerName).Info(fmt.Sprintf("Publishing response with subject %s "+
 		"for request id %s", outputSubject, responseMsg.GetRequestId()))
 
-	_, err = ms.jetstream.Publish(outputSubject, outputMsg)
+	_, err = ms.publish(outputSubject, outputMsg)
 	if err != nil {
 		ms.logger.WithName(_messagingLoggerName).
 			Error(err, fmt.Sprintf("Error publishing output for"+
@@ -108,6 +140,61 @@ func (ms Messaging) publishResponse(responseMsg *kai.KaiNatsMessage, channel str
 	}
 }
 
+func (ms Messaging) recordPublished(start time.Time, outputSubject string, responseMsg *kai.KaiNatsMessage,
+	outputMsg []byte,
+) {
+	if ms.metrics == nil {
+		return
+	}
+
+	attrs := metrics.Attributes(ms.metadata, outputSubject, responseMsg.GetFromNode())
+
+	ms.metrics.PublishLatency.Record(context.Background(), time.Since(start).Milliseconds(), attrs)
+	ms.metrics.RecordPublished(context.Background(), int64(proto.Size(responseMsg)), outputMsg, attrs)
+}
+
+// publish sends the trace context of a producer span in the headers, the root of a new trace for the messages
+// sent outside of any, such as the ones of triggers. Without a tracer provider there may be no trace to send, and
+// the plain message is kept untouched.
+func (ms Messaging) publish(subject string, data []byte) (*nats.PubAck, error) {
+	ctx := tracing.StartProducerSpan(ms.ctx, subject)
+	defer tracing.EndSpan(ctx)
+
+	header := nats.Header{}
+	if tracing.InjectHeader(ctx, header) {
+		return ms.jetstream.PublishMsg(&nats.Msg{Subject: subject, Data: data, Header: header})
+	}
+
+	return ms.jetstream.Publish(subject, data)
+}
+
+func (ms Messaging) publishReply(replySubject string, outputMsg []byte, requestID string) {
+	ms.logger.WithName(_messagingLoggerName).Info(fmt.Sprintf("Publishing response with reply subject %s "+
+		"for request id %s", replySubject, requestID))
+
+	msg := &nats.Msg{Subject: replySubject, Data: outputMsg}
+
+	// Reply subjects are unique per trigger replica, so the span is named after a temporary destination.
+	ctx := tracing.StartProducerSpan(ms.ctx, "(temporary)")
+	defer tracing.EndSpan(ctx)
+
+	header := nats.Header{}
+	if tracing.InjectHeader(ctx, header) {
+		msg.Header = header
+	}
+
+	err := ms.messagingUtils.PublishMsg(msg)
+	if err != nil {
+		ms.logger.WithName(_messagingLoggerName).
+			Error(err, fmt.Sprintf("Error publishing output for"+
+				" request id %s", requestID))
+	}
+}
+
+func (ms Messaging) isExitProcess() bool {
+	return viper.GetString(common.ConfigMetadataProcessTypeKey) == common.ProcessTypeExit
+}
+
 func (ms Messaging) getOutputSubject(channel string) string {
 	outputSubject := viper.GetString(common.ConfigNatsOutputKey)
 	if channel != "" {
@@ -139,10 +226,10 @@ func (ms Messaging) prepareOutputMessage(msg []byte) ([]byte, error) {
 	lenOutMsg := int64(len(outMsg))
 	if lenOutMsg > maxSize {
 		ms.logger.WithName(_messagingLoggerName).V(1).
-			Info("Compressed message size %s exceeds maximum size allowed %s",
+			Info(fmt.Sprintf("Compressed message size %s exceeds maximum size allowed %s",
 				sizeInMB(lenOutMsg),
 				sizeInMB(maxSize),
-			)
+			))
 
 		return nil, errors.ErrMessageToBig
 	}
@@ -3,13 +3,20 @@
 package messaging_test
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/nats-io/nats.go"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/tracing"
 	kai "github.com/konstellation-io/kai-gosdk/protos"
 	"github.com/konstellation-io/kai-gosdk/sdk/messaging"
 )
@@ -209,3 +216,174 @@ func (s *SdkMessagingTestSuite) TestMessaging_SendOutput_ErrorOnPublish_ExpectEr
 	s.messagingUtils.AssertNumberOfCalls(s.T(), "GetMaxMessageSize", 1)
 	s.jetstream.AssertNotCalled(s.T(), "Publish")
 }
+
+func (s *SdkMessagingTestSuite) TestMessaging_SendOutput_PropagatesRequestReplySubject_ExpectOk() {
+	// Given
+	viper.SetDefault(natsOutputField, natsOutputValue)
+	viper.SetDefault(metadataProcessIDField, metadataProcessIDValue)
+	s.jetstream.On("Publish", mock.AnythingOfType("string"), mock.AnythingOfType(unit8Type)).
+		Return(&nats.PubAck{}, nil)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024*1024), nil)
+
+	request := kai.KaiNatsMessage{RequestId: "123", ReplySubject: "_INBOX.replica"}
+	objectStore := messaging.NewTestMessaging(s.logger, nil, &s.jetstream, &request, &s.messagingUtils)
+
+	// When
+	msg := wrappers.StringValue{
+		Value: stringValueMessage,
+	}
+	err := objectStore.SendOutput(&msg)
+
+	// Then
+	s.Require().NoError(err)
+	s.jetstream.AssertCalled(s.T(),
+		"Publish", natsOutputValue,
+		getOutputMessageWithReplySubject("123", &msg, metadataProcessIDValue, "_INBOX.replica"))
+}
+
+func (s *SdkMessagingTestSuite) TestMessaging_SendOutputWithReplySubject_ExpectOk() {
+	// Given
+	viper.SetDefault(natsOutputField, natsOutputValue)
+	viper.SetDefault(metadataProcessIDField, metadataProcessIDValue)
+	s.jetstream.On("Publish", mock.AnythingOfType("string"), mock.AnythingOfType(unit8Type)).
+		Return(&nats.PubAck{}, nil)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024*1024), nil)
+
+	objectStore := messaging.NewTestMessaging(s.logger, nil, &s.jetstream, nil, &s.messagingUtils).
+		WithReplySubject("_INBOX.trigger")
+
+	// When
+	msg := wrappers.StringValue{
+		Value: stringValueMessage,
+	}
+	err := objectStore.SendOutputWithRequestID(&msg, "123")
+
+	// Then
+	s.Require().NoError(err)
+	s.jetstream.AssertCalled(s.T(),
+		"Publish", natsOutputValue,
+		getOutputMessageWithReplySubject("123", &msg, metadataProcessIDValue, "_INBOX.trigger"))
+}
+
+func (s *SdkMessagingTestSuite) TestMessaging_SendOutput_ExitProcessWithReplySubject_ExpectPublishedToReplySubject() {
+	// Given
+	viper.SetDefault(natsOutputField, natsOutputValue)
+	viper.SetDefault(metadataProcessIDField, metadataProcessIDValue)
+	viper.SetDefault(common.ConfigMetadataProcessTypeKey, common.ProcessTypeExit)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024*1024), nil)
+	s.messagingUtils.On("PublishMsg", mock.AnythingOfType("*nats.Msg")).Return(nil)
+
+	request := kai.KaiNatsMessage{RequestId: "123", ReplySubject: "_INBOX.replica"}
+	messagingInst := messaging.NewTestMessaging(s.logger, nil, &s.jetstream, &request, &s.messagingUtils)
+
+	// When
+	msg := wrappers.StringValue{
+		Value: stringValueMessage,
+	}
+	err := messagingInst.SendOutput(&msg)
+
+	// Then
+	s.Require().NoError(err)
+	s.messagingUtils.AssertCalled(s.T(), "PublishMsg", &nats.Msg{
+		Subject: "_INBOX.replica",
+		Data:    getOutputMessageWithReplySubject("123", &msg, metadataProcessIDValue, "_INBOX.replica"),
+	})
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	s.jetstream.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
+
+func (s *SdkMessagingTestSuite) TestMessaging_SendOutput_ExitProcessWithoutReplySubject_ExpectDiscarded() {
+	// Given
+	viper.SetDefault(metadataProcessIDField, metadataProcessIDValue)
+	viper.SetDefault(common.ConfigMetadataProcessTypeKey, common.ProcessTypeExit)
+
+	request := kai.KaiNatsMessage{RequestId: "123"}
+	messagingInst := messaging.NewTestMessaging(s.logger, nil, &s.jetstream, &request, &s.messagingUtils)
+
+	// When
+	msg := wrappers.StringValue{
+		Value: stringValueMessage,
+	}
+	err := messagingInst.SendOutput(&msg)
+
+	// Then
+	s.Require().NoError(err)
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
+
+func (s *SdkMessagingTestSuite) TestMessaging_SendOutput_TaskProcessWithReplySubject_ExpectPublishedToStream() {
+	// Given
+	viper.SetDefault(natsOutputField, natsOutputValue)
+	viper.SetDefault(metadataProcessIDField, metadataProcessIDValue)
+	viper.SetDefault(common.ConfigMetadataProcessTypeKey, common.ProcessTypeTask)
+	s.jetstream.On("Publish", mock.AnythingOfType("string"), mock.AnythingOfType(unit8Type)).
+		Return(&nats.PubAck{}, nil)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024*1024), nil)
+
+	request := kai.KaiNatsMessage{RequestId: "123", ReplySubject: "_INBOX.replica"}
+	messagingInst := messaging.NewTestMessaging(s.logger, nil, &s.jetstream, &request, &s.messagingUtils)
+
+	// When
+	msg := wrappers.StringValue{
+		Value: stringValueMessage,
+	}
+	err := messagingInst.SendOutput(&msg)
+
+	// Then
+	s.Require().NoError(err)
+	s.jetstream.AssertCalled(s.T(),
+		"Publish", natsOutputValue,
+		getOutputMessageWithReplySubject("123", &msg, metadataProcessIDValue, "_INBOX.replica"))
+	s.messagingUtils.AssertNotCalled(s.T(), "PublishMsg", mock.Anything)
+}
+
+func (s *SdkMessagingTestSuite) TestMessaging_SendOutput_WithTraceContext_ExpectTraceparentHeader() {
+	// Given
+	viper.SetDefault(natsOutputField, natsOutputValue)
+	viper.SetDefault(metadataProcessIDField, metadataProcessIDValue)
+	s.jetstream.On("PublishMsg", mock.AnythingOfType("*nats.Msg")).Return(&nats.PubAck{}, nil)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024*1024), nil)
+
+	received := nats.NewMsg("test-subject")
+	received.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx := tracing.StartSpan(context.Background(), received)
+	traceID, _ := tracing.IDs(ctx)
+
+	messagingInst := messaging.NewTestMessaging(s.logger, nil, &s.jetstream, &kai.KaiNatsMessage{}, &s.messagingUtils).
+		WithContext(ctx)
+
+	// When
+	err := messagingInst.SendOutput(&wrappers.StringValue{Value: stringValueMessage})
+
+	// Then
+	s.Require().NoError(err)
+	s.jetstream.AssertCalled(s.T(), "PublishMsg", mock.MatchedBy(func(msg *nats.Msg) bool {
+		return msg.Subject == natsOutputValue && strings.Contains(msg.Header.Get("traceparent"), traceID)
+	}))
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+}
+
+func (s *SdkMessagingTestSuite) TestMessaging_SendOutput_WithTracerProviderOutsideOfTrace_ExpectNewTrace() {
+	// Given
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider())
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	viper.SetDefault(natsOutputField, natsOutputValue)
+	viper.SetDefault(metadataProcessIDField, metadataProcessIDValue)
+	s.jetstream.On("PublishMsg", mock.AnythingOfType("*nats.Msg")).Return(&nats.PubAck{}, nil)
+	s.messagingUtils.On("GetMaxMessageSize").Return(int64(1024*1024*1024), nil)
+
+	messagingInst := messaging.NewTestMessaging(s.logger, nil, &s.jetstream, &kai.KaiNatsMessage{}, &s.messagingUtils)
+
+	// When
+	err := messagingInst.SendOutput(&wrappers.StringValue{Value: stringValueMessage})
+
+	// Then
+	s.Require().NoError(err)
+	s.jetstream.AssertCalled(s.T(), "PublishMsg", mock.MatchedBy(func(msg *nats.Msg) bool {
+		return msg.Subject == natsOutputValue && msg.Header.Get("traceparent") != ""
+	}))
+	s.jetstream.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+}
@@ -50,6 +50,21 @@ func (s *SdkMessagingTestSuite) TestMessaging_InstantiateNewMessaging_ExpectOk()
 	s.NotNil(messagingInst)
 }
 
+func (s *SdkMessagingTestSuite) TestMessaging_WithRequest_ExpectMetricsShared() {
+	// Given
+	messagingInst := messaging.New(s.logger, nil, &s.jetstream, nil)
+	request := &kai.KaiNatsMessage{RequestId: "123"}
+
+	// When
+	requestMessaging := messagingInst.WithRequest(s.logger, request)
+
+	// Then
+	s.Require().NotNil(messagingInst.GetMetrics())
+	s.Same(messagingInst.GetMetrics(), requestMessaging.GetMetrics())
+	s.Same(request, requestMessaging.GetRequestMessage())
+	s.Nil(messagingInst.GetRequestMessage())
+}
+
 func (s *SdkMessagingTestSuite) TestMessaging_PublishError_ExpectOk() {
 	// Given
 	viper.SetDefault(common.ConfigNatsOutputKey, "test-parent")
@@ -198,3 +213,18 @@ func getOutputMessage(requestID string, msg interface{},
 
 	return outputMsg
 }
+
+func getOutputMessageWithReplySubject(requestID string, msg proto.Message, fromNode, replySubject string) []byte {
+	payload, _ := anypb.New(msg)
+
+	responseMsg := &kai.KaiNatsMessage{
+		RequestId:    requestID,
+		Payload:      payload,
+		FromNode:     fromNode,
+		MessageType:  kai.MessageType_OK,
+		ReplySubject: replySubject,
+	}
+	outputMsg, _ := proto.Marshal(responseMsg)
+
+	return outputMsg
+}
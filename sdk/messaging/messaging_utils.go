@@ -12,6 +12,7 @@ import (
 //go:generate mockery --name messagingUtils --output ../../mocks --structname MessagingUtilsMock --filename messaging_utils_mock.go
 type messagingUtils interface {
 	GetMaxMessageSize() (int64, error)
+	PublishMsg(msg *nats.Msg) error
 }
 
 type MessagingUtilsImpl struct { //nolint:revive // naming is correct
@@ -46,6 +47,11 @@ func (mu MessagingUtilsImpl) GetMaxMessageSize() (int64, error) {
 	return serverMaxSize, nil
 }
 
+// PublishMsg publishes the message over core NATS, outside of any stream.
+func (mu MessagingUtilsImpl) PublishMsg(msg *nats.Msg) error {
+	return mu.nats.PublishMsg(msg)
+}
+
 func sizeInMB(size int64) string {
 	mbSize := float32(size) / 1024 / 1024
 	return fmt.Sprintf("%.1f MB", mbSize)
@@ -4,6 +4,7 @@ package persistentstorage
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/konstellation-io/kai-gosdk/internal/common"
 	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
@@ -21,6 +22,14 @@ func NewObject(info ObjectInfo, data []byte) Object {
 	}
 }
 
+// ExpireTTLRulesCheck makes the next save with a TTL check the lifecycle rules again.
+func ExpireTTLRulesCheck(ps *PersistentStorage) {
+	ps.ttlRules.mu.Lock()
+	defer ps.ttlRules.mu.Unlock()
+
+	ps.ttlRules.checkedAt = time.Time{}
+}
+
 func NewPersistentStorageIntegration(logger logr.Logger) (*PersistentStorage, error) {
 	persistentStorageBucket := viper.GetString(common.ConfigMinioBucketKey)
 
@@ -34,6 +43,7 @@ func NewPersistentStorageIntegration(logger logr.Logger) (*PersistentStorage, er
 		storageClient: storageManager,
 		storageBucket: persistentStorageBucket,
 		metadata:      metadata.New(),
+		ttlRules:      newTTLRules(),
 	}, nil
 }
 
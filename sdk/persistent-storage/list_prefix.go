@@ -0,0 +1,199 @@
+package persistentstorage
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/spf13/viper"
+
+	"github.com/konstellation-io/kai-gosdk/internal/common"
+	"github.com/konstellation-io/kai-gosdk/internal/errors"
+)
+
+const _maxListPageSize = 1000
+
+type ListPrefixOption func(*listPrefixOptions)
+
+type listPrefixOptions struct {
+	delimiter         string
+	pageSize          int
+	continuationToken string
+}
+
+// WithDelimiter lists the objects by directory, grouping the keys below the prefix that contain the delimiter, e.g.
+// "/", into a directory entry per common prefix.
+func WithDelimiter(delimiter string) ListPrefixOption {
+	return func(o *listPrefixOptions) {
+		o.delimiter = delimiter
+	}
+}
+
+// WithPageSize sets the objects requested at a time, 1000 by default and at most.
+func WithPageSize(size int) ListPrefixOption {
+	return func(o *listPrefixOptions) {
+		o.pageSize = size
+	}
+}
+
+// WithContinuationToken resumes a listing after the last object returned by a previous iterator.
+func WithContinuationToken(token string) ListPrefixOption {
+	return func(o *listPrefixOptions) {
+		o.continuationToken = token
+	}
+}
+
+// ObjectIterator iterates the objects of a listing, requesting a page of objects only once the previous one is read.
+// The objects only have the info returned by a plain listing: their version, metadata, provenance, content type and
+// tags are empty, and ExpiresIn is only set from the expiration reported by the storage. Use Get to read them.
+type ObjectIterator struct {
+	ctx       context.Context
+	ps        PersistentStorage
+	prefix    string
+	delimiter string
+	pageSize  int
+	// startAfter is the key the listing resumes after, skipped when returned again as a directory.
+	startAfter string
+	pageToken  string
+	page       []*ObjectInfo
+	current    *ObjectInfo
+	lastKey    string
+	truncated  bool
+	err        error
+}
+
+// ListPrefix lists the objects whose key starts with the prefix, in lexical order. Only the info returned by the
+// listing is set, so objects have no version, metadata nor tags. Directories have no info besides the key.
+func (ps PersistentStorage) ListPrefix(ctx context.Context, prefix string,
+	opts ...ListPrefixOption,
+) (*ObjectIterator, error) {
+	options := listPrefixOptions{pageSize: _maxListPageSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.pageSize <= 0 || options.pageSize > _maxListPageSize {
+		options.pageSize = _maxListPageSize
+	}
+
+	startAfter, err := decodeContinuationToken(options.continuationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectIterator{
+		ctx:        ctx,
+		ps:         ps,
+		prefix:     prefix,
+		delimiter:  options.delimiter,
+		pageSize:   options.pageSize,
+		startAfter: startAfter,
+		lastKey:    startAfter,
+		truncated:  true,
+	}, nil
+}
+
+// Next advances to the next object, returning false when the listing ends or fails.
+func (it *ObjectIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || !it.truncated {
+			it.current = nil
+			return false
+		}
+
+		it.err = it.fetchPage()
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	it.lastKey = it.current.Key
+
+	return true
+}
+
+// Object returns the current object.
+func (it *ObjectIterator) Object() *ObjectInfo {
+	return it.current
+}
+
+// Err returns the error that ended the listing, if any.
+func (it *ObjectIterator) Err() error {
+	return it.err
+}
+
+// ContinuationToken returns the token resuming the listing after the current object, empty if there are no objects
+// left or none was returned yet.
+func (it *ObjectIterator) ContinuationToken() string {
+	if (len(it.page) == 0 && !it.truncated) || it.lastKey == "" {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(it.lastKey))
+}
+
+func (it *ObjectIterator) fetchPage() error {
+	if err := it.ctx.Err(); err != nil {
+		return err
+	}
+
+	core := minio.Core{Client: it.ps.storageClient}
+
+	startAfter := ""
+	if it.pageToken == "" {
+		startAfter = it.startAfter
+	}
+
+	result, err := core.ListObjectsV2(it.ps.storageBucket, it.prefix, startAfter, it.pageToken, it.delimiter,
+		it.pageSize)
+	if err != nil {
+		return fmt.Errorf("error listing objects from the persistent storage: %w", err)
+	}
+
+	it.pageToken = result.NextContinuationToken
+	it.truncated = result.IsTruncated
+
+	internalFolder := viper.GetString(common.ConfigMinioInternalFolderKey)
+
+	for _, object := range result.Contents {
+		if object.Key != "" && !isInternal(object.Key, internalFolder) {
+			object.ETag = strings.Trim(object.ETag, "\"")
+			it.page = append(it.page, newObjectInfo(object.Key, object, nil, nil))
+		}
+	}
+
+	for _, commonPrefix := range result.CommonPrefixes {
+		// A listing resumed after a directory returns it again, as its keys come after it.
+		if commonPrefix.Prefix != it.startAfter && !isInternal(commonPrefix.Prefix, internalFolder) {
+			it.page = append(it.page, &ObjectInfo{Key: commonPrefix.Prefix, IsDirectory: true})
+		}
+	}
+
+	sort.Slice(it.page, func(i, j int) bool {
+		return it.page[i].Key < it.page[j].Key
+	})
+
+	it.ps.logger.WithName(_persistentStorageLoggerName).V(1).
+		Info(fmt.Sprintf("Page of %d objects successfully listed for prefix %q from persistent storage",
+			len(it.page), it.prefix))
+
+	return nil
+}
+
+func isInternal(key, internalFolder string) bool {
+	return internalFolder != "" && strings.HasPrefix(key, internalFolder)
+}
+
+func decodeContinuationToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	startAfter, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(startAfter) == 0 {
+		return "", errors.ErrInvalidContinuationToken
+	}
+
+	return string(startAfter), nil
+}
@@ -0,0 +1,244 @@
+package persistentstorage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	_userMetadataPrefix  = "x-amz-meta-"
+	_contentTypeMetadata = "content-type"
+	_defaultContentType  = "application/octet-stream"
+)
+
+type ObjectInfo struct {
+	Key          string
+	VersionID    string
+	ExpiresIn    time.Time
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+	// Metadata is the custom metadata of the object, with lower case keys.
+	Metadata map[string]string
+	Tags     map[string]string
+	// Product, Version, Workflow and Process are the provenance of the object, the process that saved it.
+	Product  string
+	Version  string
+	Workflow string
+	Process  string
+	// IsDirectory is set for the common prefixes of listings by directory, which are not objects.
+	IsDirectory bool
+}
+
+// ListOption filters the objects returned by List. The filters are applied by the SDK on the full listing of the
+// bucket, as the storage can't filter by metadata nor tags: the provenance filters use the metadata returned by the
+// listing, and filtering by tag reads the tags of every object left by them.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	tags       map[string]string
+	provenance map[string]string
+}
+
+// FilterByTag lists only the objects with the given tag value.
+func FilterByTag(key, value string) ListOption {
+	return func(o *listOptions) {
+		o.tags[key] = value
+	}
+}
+
+// FilterByProduct lists only the objects saved by the given product.
+func FilterByProduct(product string) ListOption {
+	return filterByProvenance(_productMetadata, product)
+}
+
+// FilterByVersion lists only the objects saved by the given product version.
+func FilterByVersion(version string) ListOption {
+	return filterByProvenance(_versionMetadata, version)
+}
+
+// FilterByWorkflow lists only the objects saved by the given workflow.
+func FilterByWorkflow(workflow string) ListOption {
+	return filterByProvenance(_workflowMetadata, workflow)
+}
+
+// FilterByProcess lists only the objects saved by the given process.
+func FilterByProcess(process string) ListOption {
+	return filterByProvenance(_processMetadata, process)
+}
+
+func filterByProvenance(key, value string) ListOption {
+	return func(o *listOptions) {
+		o.provenance[key] = value
+	}
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	options := listOptions{
+		tags:       make(map[string]string),
+		provenance: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+func (o listOptions) matchesTags(info *ObjectInfo) bool {
+	for key, value := range o.tags {
+		if tag, ok := info.Tags[key]; !ok || tag != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (o listOptions) matchesProvenance(info *ObjectInfo) bool {
+	provenance := map[string]string{
+		_productMetadata:  info.Product,
+		_versionMetadata:  info.Version,
+		_workflowMetadata: info.Workflow,
+		_processMetadata:  info.Process,
+	}
+
+	for key, value := range o.provenance {
+		if provenance[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getObjectInfo returns the info of a stated object, reading its tags only if it has any.
+func (ps PersistentStorage) getObjectInfo(ctx context.Context, key string, stats minio.ObjectInfo) (*ObjectInfo, error) {
+	var objectTags map[string]string
+
+	if stats.UserTagCount > 0 {
+		var err error
+
+		objectTags, err = ps.getObjectTags(ctx, key, stats.VersionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newObjectInfo(key, stats, statMetadata(stats.UserMetadata), objectTags), nil
+}
+
+// newObjectInfo builds the info of an object from its stats or listing, its user metadata and its tags.
+func newObjectInfo(key string, object minio.ObjectInfo, userMetadata, objectTags map[string]string) *ObjectInfo {
+	info := &ObjectInfo{
+		Key:          key,
+		VersionID:    object.VersionID,
+		ExpiresIn:    objectExpiration(object.LastModified, objectTags, object.Expiration),
+		Size:         object.Size,
+		ContentType:  object.ContentType,
+		ETag:         object.ETag,
+		LastModified: object.LastModified,
+		Metadata:     make(map[string]string),
+		Tags:         make(map[string]string),
+	}
+
+	for name, value := range userMetadata {
+		switch name {
+		case _productMetadata:
+			info.Product = value
+		case _versionMetadata:
+			info.Version = value
+		case _workflowMetadata:
+			info.Workflow = value
+		case _processMetadata:
+			info.Process = value
+		default:
+			info.Metadata[name] = value
+		}
+	}
+
+	for name, value := range objectTags {
+		if name != _ttlTag {
+			info.Tags[name] = value
+		}
+	}
+
+	return info
+}
+
+// listedObjectInfo builds the info of an object from a listing with metadata, which reports the content type along
+// with the user metadata.
+func listedObjectInfo(object minio.ObjectInfo) *ObjectInfo {
+	if object.ContentType == "" {
+		for name, value := range object.UserMetadata {
+			if strings.EqualFold(name, _contentTypeMetadata) {
+				object.ContentType = value
+			}
+		}
+	}
+
+	return newObjectInfo(object.Key, object, listingMetadata(object.UserMetadata), object.UserTags)
+}
+
+// statMetadata returns the user metadata of stated objects, whose keys come without prefix.
+func statMetadata(metadata map[string]string) map[string]string {
+	userMetadata := make(map[string]string, len(metadata))
+	for name, value := range metadata {
+		userMetadata[strings.ToLower(name)] = value
+	}
+
+	return userMetadata
+}
+
+// listingMetadata returns the user metadata of listed objects, whose keys come with the user metadata prefix along
+// with other headers.
+func listingMetadata(metadata map[string]string) map[string]string {
+	userMetadata := make(map[string]string, len(metadata))
+
+	for name, value := range metadata {
+		lowerName := strings.ToLower(name)
+		if strings.HasPrefix(lowerName, _userMetadataPrefix) {
+			userMetadata[strings.TrimPrefix(lowerName, _userMetadataPrefix)] = value
+		}
+	}
+
+	return userMetadata
+}
+
+// putMetadata returns the user metadata of an object to save, the provenance overriding custom keys.
+func (ps PersistentStorage) putMetadata(custom map[string]string) map[string]string {
+	userMetadata := make(map[string]string, len(custom)+4)
+	for name, value := range custom {
+		userMetadata[strings.ToLower(name)] = value
+	}
+
+	userMetadata[_productMetadata] = ps.metadata.GetProduct()
+	userMetadata[_versionMetadata] = ps.metadata.GetVersion()
+	userMetadata[_workflowMetadata] = ps.metadata.GetWorkflow()
+	userMetadata[_processMetadata] = ps.metadata.GetProcess()
+
+	return userMetadata
+}
+
+// putTags returns the tags of an object to save, the TTL one set only from the given days.
+func putTags(custom map[string]string, ttlDays int) map[string]string {
+	objectTags := make(map[string]string, len(custom)+1)
+
+	for name, value := range custom {
+		if name != _ttlTag {
+			objectTags[name] = value
+		}
+	}
+
+	if ttlDays > 0 {
+		objectTags[_ttlTag] = strconv.Itoa(ttlDays)
+	}
+
+	return objectTags
+}
@@ -0,0 +1,86 @@
+package persistentstorage
+
+const _defaultReadRetries = 3
+
+// ProgressFunc is called with the bytes transferred so far and the total size of the object, -1 if unknown.
+type ProgressFunc func(transferred, total int64)
+
+type ObjectOption func(*objectOptions)
+
+type objectOptions struct {
+	ttlDays     int
+	partSize    uint64
+	offset      int64
+	retries     int
+	progress    ProgressFunc
+	metadata    map[string]string
+	tags        map[string]string
+	contentType string
+}
+
+// WithTTL sets the days after which a saved object expires, rounded up to the next TTL class.
+func WithTTL(days int) ObjectOption {
+	return func(o *objectOptions) {
+		o.ttlDays = days
+	}
+}
+
+// WithPartSize sets the size of the parts a stream is uploaded in. Streams of unknown size are uploaded in parts
+// of 16 MiB by default, which allows objects of up to 160 GB, as an upload has at most 10000 parts.
+func WithPartSize(size uint64) ObjectOption {
+	return func(o *objectOptions) {
+		o.partSize = size
+	}
+}
+
+// WithOffset starts reading a stream from the given byte, e.g. to resume a previous read.
+func WithOffset(offset int64) ObjectOption {
+	return func(o *objectOptions) {
+		o.offset = offset
+	}
+}
+
+// WithRetries sets the times a stream is resumed after consecutive read failures, 3 by default.
+func WithRetries(retries int) ObjectOption {
+	return func(o *objectOptions) {
+		o.retries = retries
+	}
+}
+
+// WithProgress sets a function called as a stream is uploaded or read.
+func WithProgress(progress ProgressFunc) ObjectOption {
+	return func(o *objectOptions) {
+		o.progress = progress
+	}
+}
+
+// WithMetadata sets custom metadata of a saved object. Keys are case-insensitive, returned in lower case, and the
+// product, version, workflow and process keys are reserved for the provenance of the object.
+func WithMetadata(metadata map[string]string) ObjectOption {
+	return func(o *objectOptions) {
+		o.metadata = metadata
+	}
+}
+
+// WithTags sets the tags of a saved object, up to 10 including the TTL one.
+func WithTags(tags map[string]string) ObjectOption {
+	return func(o *objectOptions) {
+		o.tags = tags
+	}
+}
+
+// WithContentType sets the content type of a saved object.
+func WithContentType(contentType string) ObjectOption {
+	return func(o *objectOptions) {
+		o.contentType = contentType
+	}
+}
+
+func newObjectOptions(opts []ObjectOption) objectOptions {
+	options := objectOptions{retries: _defaultReadRetries}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
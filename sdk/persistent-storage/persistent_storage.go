@@ -11,7 +11,6 @@ import (
 	"github.com/konstellation-io/kai-gosdk/internal/storage"
 
 	"github.com/konstellation-io/kai-gosdk/sdk/metadata"
-	"github.com/minio/minio-go/v7/pkg/lifecycle"
 
 	"github.com/go-logr/logr"
 	"github.com/konstellation-io/kai-gosdk/internal/common"
@@ -22,6 +21,7 @@ import (
 
 const (
 	_persistentStorageLoggerName = "[PERSISTENT STORAGE]"
+	_noSuchKeyError              = "NoSuchKey"
 
 	_productMetadata  = "product"
 	_versionMetadata  = "version"
@@ -34,12 +34,7 @@ type PersistentStorage struct {
 	storageClient *minio.Client
 	storageBucket string
 	metadata      *metadata.Metadata
-}
-
-type ObjectInfo struct {
-	Key       string
-	VersionID string
-	ExpiresIn time.Time
+	ttlRules      *ttlRules
 }
 
 type Object struct {
@@ -68,38 +63,50 @@ func New(logger logr.Logger, meta *metadata.Metadata) (*PersistentStorage, error
 		storageClient: storageClient,
 		storageBucket: persistentStorageBucket,
 		metadata:      meta,
+		ttlRules:      newTTLRules(),
 	}, nil
 }
 
 func (ps PersistentStorage) Save(key string, payload []byte, ttlDays ...int) (*ObjectInfo, error) {
-	ctx := context.Background()
-
-	if key == "" {
-		return nil, errors.ErrEmptyKey
-	}
-
-	if strings.HasPrefix(key, viper.GetString(common.ConfigMinioInternalFolderKey)) {
-		return nil, errors.ErrInvalidKey
+	if err := validateKey(key); err != nil {
+		return nil, err
 	}
 
 	if len(payload) == 0 {
 		return nil, errors.ErrEmptyPayload
 	}
 
-	err := ps.addLifecycleDeletionRule(ctx, key, ttlDays)
-	if err != nil {
-		return nil, fmt.Errorf("error adding lifecycle deletion rule: %w", err)
+	var options objectOptions
+	if len(ttlDays) > 0 {
+		options.ttlDays = ttlDays[0]
 	}
 
-	reader := bytes.NewReader(payload)
+	return ps.putObject(context.Background(), key, bytes.NewReader(payload), int64(len(payload)), options)
+}
+
+func (ps PersistentStorage) putObject(ctx context.Context, key string, reader io.Reader, size int64,
+	options objectOptions,
+) (*ObjectInfo, error) {
+	if options.ttlDays > 0 {
+		ttlDays, err := ttlClass(options.ttlDays)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ps.ensureTTLRules(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error adding lifecycle deletion rules: %w", err)
+		}
+
+		options.ttlDays = ttlDays
+	}
 
 	opts := minio.PutObjectOptions{
-		UserMetadata: map[string]string{
-			_productMetadata:  ps.metadata.GetProduct(),
-			_versionMetadata:  ps.metadata.GetVersion(),
-			_workflowMetadata: ps.metadata.GetWorkflow(),
-			_processMetadata:  ps.metadata.GetProcess(),
-		},
+		UserMetadata: ps.putMetadata(options.metadata),
+		UserTags:     putTags(options.tags, options.ttlDays),
+		ContentType:  options.contentType,
+		PartSize:     options.partSize,
+		Progress:     newProgressReader(options.progress, size),
 	}
 
 	info, err := ps.storageClient.PutObject(
@@ -107,7 +114,7 @@ func (ps PersistentStorage) Save(key string, payload []byte, ttlDays ...int) (*O
 		ps.storageBucket,
 		key,
 		reader,
-		int64(reader.Len()),
+		size,
 		opts,
 	)
 	if err != nil {
@@ -118,22 +125,29 @@ func (ps PersistentStorage) Save(key string, payload []byte, ttlDays ...int) (*O
 		Info(fmt.Sprintf("Object %s successfully stored in persistent storage with version ID %s",
 			key, info.VersionID))
 
-	obj := &ObjectInfo{
-		Key:       key,
-		VersionID: info.VersionID,
-		ExpiresIn: info.Expiration,
+	lastModified := info.LastModified
+	if lastModified.IsZero() {
+		lastModified = time.Now()
 	}
 
-	return obj, nil
+	contentType := options.contentType
+	if contentType == "" {
+		contentType = _defaultContentType
+	}
+
+	return newObjectInfo(key, minio.ObjectInfo{
+		VersionID:    info.VersionID,
+		Expiration:   info.Expiration,
+		Size:         info.Size,
+		ContentType:  contentType,
+		ETag:         info.ETag,
+		LastModified: lastModified,
+	}, opts.UserMetadata, opts.UserTags), nil
 }
 
 func (ps PersistentStorage) Get(key string, version ...string) (*Object, error) {
-	if key == "" {
-		return nil, errors.ErrEmptyKey
-	}
-
-	if strings.HasPrefix(key, viper.GetString(common.ConfigMinioInternalFolderKey)) {
-		return nil, errors.ErrInvalidKey
+	if err := validateKey(key); err != nil {
+		return nil, err
 	}
 
 	opts := minio.GetObjectOptions{}
@@ -169,23 +183,31 @@ func (ps PersistentStorage) Get(key string, version ...string) (*Object, error)
 		return nil, fmt.Errorf("error getting object stats from the persistent storage: %w", err)
 	}
 
+	objectInfo, err := ps.getObjectInfo(context.Background(), key, objStats)
+	if err != nil {
+		return nil, err
+	}
+
 	obj := &Object{
-		ObjectInfo: ObjectInfo{
-			Key:       key,
-			VersionID: objStats.VersionID,
-			ExpiresIn: objStats.Expiration,
-		},
-		data: data,
+		ObjectInfo: *objectInfo,
+		data:       data,
 	}
 
 	return obj, nil
 }
 
-func (ps PersistentStorage) List() ([]*ObjectInfo, error) {
+// List returns the latest version of the objects of the bucket, only those matching every filter if any given.
+// The info of the objects comes from a listing with metadata, which reports no version. Their tags are only read
+// when filtering by tag, otherwise they are set as reported by the listing, if at all, so large buckets are better
+// listed by prefix.
+func (ps PersistentStorage) List(opts ...ListOption) ([]*ObjectInfo, error) {
 	var objectList []*ObjectInfo
 
+	options := newListOptions(opts)
+	ctx := context.Background()
+
 	objects := ps.storageClient.ListObjects(
-		context.Background(),
+		ctx,
 		ps.storageBucket,
 		minio.ListObjectsOptions{
 			WithMetadata: true,
@@ -193,27 +215,48 @@ func (ps PersistentStorage) List() ([]*ObjectInfo, error) {
 		},
 	)
 
-	ps.logger.WithName(_persistentStorageLoggerName).V(1).
-		Info("Objects successfully retrieved from persistent storage")
+	internalFolder := viper.GetString(common.ConfigMinioInternalFolderKey)
 
 	for object := range objects {
-		if object.Key != "" && !strings.HasPrefix(object.Key, viper.GetString(common.ConfigMinioInternalFolderKey)) {
-			stats, err := ps.storageClient.StatObject(context.Background(), ps.storageBucket, object.Key, minio.StatObjectOptions{})
+		if object.Err != nil {
+			return nil, fmt.Errorf("error listing objects from the persistent storage: %w", object.Err)
+		}
+
+		if object.Key == "" || isInternal(object.Key, internalFolder) {
+			continue
+		}
+
+		objectInfo := listedObjectInfo(object)
+		if !options.matchesProvenance(objectInfo) {
+			continue
+		}
+
+		if len(options.tags) > 0 {
+			objectTags, err := ps.storageClient.GetObjectTagging(ctx, ps.storageBucket, object.Key,
+				minio.GetObjectTaggingOptions{})
 			if err != nil {
-				return nil, fmt.Errorf("error getting object stats from the persistent storage: %w", err)
+				// Objects deleted since they were listed are left out.
+				if minio.ToErrorResponse(err).Code == _noSuchKeyError {
+					continue
+				}
+
+				return nil, fmt.Errorf("error getting object tags from the persistent storage: %w", err)
 			}
 
-			objectList = append(
-				objectList,
-				&ObjectInfo{
-					Key:       object.Key,
-					VersionID: stats.VersionID,
-					ExpiresIn: stats.Expiration,
-				},
-			)
+			object.UserTags = objectTags.ToMap()
+			objectInfo = listedObjectInfo(object)
+
+			if !options.matchesTags(objectInfo) {
+				continue
+			}
 		}
+
+		objectList = append(objectList, objectInfo)
 	}
 
+	ps.logger.WithName(_persistentStorageLoggerName).V(1).
+		Info("Objects successfully retrieved from persistent storage")
+
 	return objectList, nil
 }
 
@@ -240,14 +283,7 @@ func (ps PersistentStorage) ListVersions(key string) ([]*ObjectInfo, error) {
 
 	for object := range objects {
 		if object.VersionID != "" && !strings.HasPrefix(object.Key, viper.GetString(common.ConfigMinioInternalFolderKey)) {
-			objectList = append(
-				objectList,
-				&ObjectInfo{
-					Key:       object.Key,
-					VersionID: object.VersionID,
-					ExpiresIn: object.Expiration,
-				},
-			)
+			objectList = append(objectList, listedObjectInfo(object))
 		}
 	}
 
@@ -255,12 +291,8 @@ func (ps PersistentStorage) ListVersions(key string) ([]*ObjectInfo, error) {
 }
 
 func (ps PersistentStorage) Delete(key string, version ...string) error {
-	if key == "" {
-		return errors.ErrEmptyKey
-	}
-
-	if strings.HasPrefix(key, viper.GetString(common.ConfigMinioInternalFolderKey)) {
-		return errors.ErrInvalidKey
+	if err := validateKey(key); err != nil {
+		return err
 	}
 
 	opts := minio.RemoveObjectOptions{
@@ -287,41 +319,14 @@ func (ps PersistentStorage) Delete(key string, version ...string) error {
 	return nil
 }
 
-func (ps PersistentStorage) addLifecycleDeletionRule(ctx context.Context, key string, ttlDays []int) error {
-	if len(ttlDays) > 0 && ttlDays[0] > 0 {
-		lc, err := ps.storageClient.GetBucketLifecycle(ctx, ps.storageBucket)
-		if err != nil {
-			lc = lifecycle.NewConfiguration()
-		}
-
-		rule := lifecycle.Rule{
-			ID:     fmt.Sprintf("ttl-%s", key),
-			Status: minio.Enabled,
-			RuleFilter: lifecycle.Filter{
-				Prefix: key,
-			},
-			Expiration: lifecycle.Expiration{
-				Days: lifecycle.ExpirationDays(ttlDays[0]),
-			},
-		}
-
-		found := false
-
-		for _, r := range lc.Rules {
-			if r.ID == rule.ID {
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			lc.Rules = append(lc.Rules, rule)
-		}
+// validateKey checks the key is set and outside the folder internally used by KAI.
+func validateKey(key string) error {
+	if key == "" {
+		return errors.ErrEmptyKey
+	}
 
-		err = ps.storageClient.SetBucketLifecycle(ctx, ps.storageBucket, lc)
-		if err != nil {
-			return err
-		}
+	if strings.HasPrefix(key, viper.GetString(common.ConfigMinioInternalFolderKey)) {
+		return errors.ErrInvalidKey
 	}
 
 	return nil
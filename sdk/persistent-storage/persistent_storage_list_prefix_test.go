@@ -0,0 +1,100 @@
+//go:build integration
+
+package persistentstorage_test
+
+import (
+	"context"
+
+	"github.com/konstellation-io/kai-gosdk/internal/errors"
+	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
+)
+
+func (s *SdkPersistentStorageTestSuite) listKeys(iterator *persistentstorage.ObjectIterator, limit int) []string {
+	var keys []string
+
+	for len(keys) < limit && iterator.Next() {
+		keys = append(keys, iterator.Object().Key)
+	}
+
+	s.Require().NoError(iterator.Err())
+
+	return keys
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListPrefix_ExpectObjectsWithPrefix() {
+	// GIVEN
+	for _, key := range []string{"images/a", "images/b", "images/c", "texts/a"} {
+		_, err := s.persistentStorage.Save(key, []byte("some-data"))
+		s.Require().NoError(err)
+	}
+
+	// WHEN
+	iterator, err := s.persistentStorage.ListPrefix(context.Background(), "images/",
+		persistentstorage.WithPageSize(2))
+	s.Require().NoError(err)
+
+	// THEN
+	s.Require().True(iterator.Next())
+	object := iterator.Object()
+	s.Assert().Equal("images/a", object.Key)
+	s.Assert().Equal(int64(len("some-data")), object.Size)
+	s.Assert().NotEmpty(object.ETag)
+	s.Assert().False(object.LastModified.IsZero())
+	s.Assert().False(object.IsDirectory)
+
+	s.Assert().Equal([]string{"images/b", "images/c"}, s.listKeys(iterator, 10))
+	s.Assert().Empty(iterator.ContinuationToken())
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListPrefixWithContinuationToken_ExpectRemainingObjects() {
+	// GIVEN
+	for _, key := range []string{"a", "b", "c", "d"} {
+		_, err := s.persistentStorage.Save(key, []byte("some-data"))
+		s.Require().NoError(err)
+	}
+
+	iterator, err := s.persistentStorage.ListPrefix(context.Background(), "", persistentstorage.WithPageSize(3))
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"a", "b"}, s.listKeys(iterator, 2))
+
+	// WHEN
+	iterator, err = s.persistentStorage.ListPrefix(context.Background(), "",
+		persistentstorage.WithContinuationToken(iterator.ContinuationToken()))
+	s.Require().NoError(err)
+
+	// THEN
+	s.Assert().Equal([]string{"c", "d"}, s.listKeys(iterator, 10))
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListPrefixWithDelimiter_ExpectDirectories() {
+	// GIVEN
+	for _, key := range []string{"a", "images/2024/a", "images/b", "texts/a", "z"} {
+		_, err := s.persistentStorage.Save(key, []byte("some-data"))
+		s.Require().NoError(err)
+	}
+
+	// WHEN
+	iterator, err := s.persistentStorage.ListPrefix(context.Background(), "",
+		persistentstorage.WithDelimiter("/"), persistentstorage.WithPageSize(2))
+	s.Require().NoError(err)
+
+	s.Require().Equal([]string{"a", "images/"}, s.listKeys(iterator, 2))
+	s.Assert().True(iterator.Object().IsDirectory)
+
+	resumed, err := s.persistentStorage.ListPrefix(context.Background(), "",
+		persistentstorage.WithDelimiter("/"), persistentstorage.WithContinuationToken(iterator.ContinuationToken()))
+	s.Require().NoError(err)
+
+	// THEN
+	s.Assert().Equal([]string{"texts/", "z"}, s.listKeys(resumed, 10))
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListPrefixWithInvalidToken_ExpectError() {
+	// WHEN
+	iterator, err := s.persistentStorage.ListPrefix(context.Background(), "",
+		persistentstorage.WithContinuationToken("not a token"))
+
+	// THEN
+	s.Assert().ErrorIs(err, errors.ErrInvalidContinuationToken)
+	s.Assert().Nil(iterator)
+}
@@ -30,7 +30,7 @@ func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListObject_ExpectO
 	s.Assert().NoError(err)
 	s.Assert().Len(listObjects, 1)
 	s.Assert().Equal(obj.Key, listObjects[0].Key)
-	s.Assert().Equal(obj.VersionID, listObjects[0].VersionID)
+	s.Assert().Equal(obj.Size, listObjects[0].Size)
 }
 
 func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListObject_EmptyList_ExpectOK() {
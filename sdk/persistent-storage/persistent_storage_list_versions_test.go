@@ -6,8 +6,6 @@ import (
 	"bytes"
 	"context"
 
-	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
-
 	"github.com/konstellation-io/kai-gosdk/internal/errors"
 	"github.com/minio/minio-go/v7"
 )
@@ -42,10 +40,12 @@ func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListObjectVersions
 	// THEN
 	s.Assert().NoError(err)
 	s.Assert().Len(objectVersions, 2)
-	s.Assert().Equal(objectVersions, []*persistentstorage.ObjectInfo{
-		{Key: key, VersionID: obj2.VersionID},
-		{Key: key, VersionID: obj.VersionID},
-	})
+	s.Assert().Equal(key, objectVersions[0].Key)
+	s.Assert().Equal(obj2.VersionID, objectVersions[0].VersionID)
+	s.Assert().Equal(int64(len(data2)), objectVersions[0].Size)
+	s.Assert().Equal(key, objectVersions[1].Key)
+	s.Assert().Equal(obj.VersionID, objectVersions[1].VersionID)
+	s.Assert().Equal(int64(len(data)), objectVersions[1].Size)
 }
 
 func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListObjectVersions_EmptyList_ExpectOK() {
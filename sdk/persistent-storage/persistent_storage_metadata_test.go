@@ -0,0 +1,88 @@
+//go:build integration
+
+package persistentstorage_test
+
+import (
+	"bytes"
+	"context"
+
+	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
+)
+
+func (s *SdkPersistentStorageTestSuite) saveWithOptions(key string, opts ...persistentstorage.ObjectOption) {
+	data := []byte("some-data")
+
+	_, err := s.persistentStorage.SaveStream(context.Background(), key, bytes.NewReader(data), int64(len(data)),
+		opts...)
+	s.Require().NoError(err)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveWithMetadataAndTags_ExpectObjectInfo() {
+	// GIVEN
+	key := "some-object"
+	data := []byte(`{"some":"data"}`)
+
+	// WHEN
+	savedInfo, err := s.persistentStorage.SaveStream(context.Background(), key, bytes.NewReader(data),
+		int64(len(data)),
+		persistentstorage.WithMetadata(map[string]string{"Owner": "some-owner", "product": "another-product"}),
+		persistentstorage.WithTags(map[string]string{"stage": "raw"}),
+		persistentstorage.WithContentType("application/json"),
+		persistentstorage.WithTTL(5),
+	)
+	s.Require().NoError(err)
+
+	object, err := s.persistentStorage.Get(key)
+
+	// THEN
+	s.Require().NoError(err)
+
+	for _, objectInfo := range []persistentstorage.ObjectInfo{*savedInfo, object.ObjectInfo} {
+		s.Assert().Equal(map[string]string{"owner": "some-owner"}, objectInfo.Metadata)
+		s.Assert().Equal(map[string]string{"stage": "raw"}, objectInfo.Tags)
+		s.Assert().Equal("application/json", objectInfo.ContentType)
+		s.Assert().Equal(int64(len(data)), objectInfo.Size)
+		s.Assert().NotEmpty(objectInfo.ETag)
+		s.Assert().False(objectInfo.LastModified.IsZero())
+		s.Assert().Equal("some-product", objectInfo.Product)
+		s.Assert().Equal("some-version", objectInfo.Version)
+		s.Assert().Equal("some-workflow", objectInfo.Workflow)
+		s.Assert().Equal("some-process", objectInfo.Process)
+	}
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListFilteredByTag_ExpectMatchingObjects() {
+	// GIVEN
+	s.saveWithOptions("raw-object", persistentstorage.WithTags(map[string]string{"stage": "raw"}))
+	s.saveWithOptions("clean-object", persistentstorage.WithTags(map[string]string{"stage": "clean"}))
+	s.saveWithOptions("untagged-object")
+
+	// WHEN
+	objects, err := s.persistentStorage.List(persistentstorage.FilterByTag("stage", "raw"))
+
+	// THEN
+	s.Require().NoError(err)
+	s.Require().Len(objects, 1)
+	s.Assert().Equal("raw-object", objects[0].Key)
+	s.Assert().Equal(map[string]string{"stage": "raw"}, objects[0].Tags)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_ListFilteredByProvenance_ExpectMatchingObjects() {
+	// GIVEN
+	s.saveWithOptions("some-object")
+
+	// WHEN
+	matching, err := s.persistentStorage.List(
+		persistentstorage.FilterByProduct("some-product"),
+		persistentstorage.FilterByProcess("some-process"),
+	)
+	s.Require().NoError(err)
+
+	notMatching, err := s.persistentStorage.List(persistentstorage.FilterByWorkflow("other-workflow"))
+	s.Require().NoError(err)
+
+	// THEN
+	s.Require().Len(matching, 1)
+	s.Assert().Equal("some-object", matching[0].Key)
+	s.Assert().Empty(notMatching)
+}
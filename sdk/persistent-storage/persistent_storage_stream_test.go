@@ -0,0 +1,119 @@
+//go:build integration
+
+package persistentstorage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/konstellation-io/kai-gosdk/internal/errors"
+	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
+)
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveStream_ExpectOK() {
+	// GIVEN
+	key := "some-stream"
+	data := bytes.Repeat([]byte("some-data"), 1000)
+
+	var transferred, total int64
+
+	// WHEN
+	objectInfo, err := s.persistentStorage.SaveStream(context.Background(), key, bytes.NewReader(data),
+		int64(len(data)), persistentstorage.WithProgress(func(t, size int64) {
+			transferred, total = t, size
+		}))
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().Equal(key, objectInfo.Key)
+	s.Assert().NotEmpty(objectInfo.VersionID)
+	s.Assert().Equal(int64(len(data)), transferred)
+	s.Assert().Equal(int64(len(data)), total)
+
+	object, err := s.persistentStorage.Get(key)
+	s.Require().NoError(err)
+	s.Assert().Equal(data, object.GetBytes())
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveStreamOfUnknownSize_ExpectMultipartUpload() {
+	// GIVEN
+	key := "some-stream"
+	partSize := uint64(5 << 20)
+	data := bytes.Repeat([]byte("a"), int(2*partSize+1))
+
+	// WHEN
+	objectInfo, err := s.persistentStorage.SaveStream(context.Background(), key, io.MultiReader(bytes.NewReader(data)),
+		-1, persistentstorage.WithPartSize(partSize))
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().NotEmpty(objectInfo.VersionID)
+
+	object, err := s.persistentStorage.Get(key)
+	s.Require().NoError(err)
+	s.Assert().Equal(len(data), len(object.GetBytes()))
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveStreamWithNoPayload_ExpectError() {
+	// WHEN
+	objectInfo, err := s.persistentStorage.SaveStream(context.Background(), "some-stream", bytes.NewReader(nil), 0)
+
+	// THEN
+	s.Assert().ErrorIs(err, errors.ErrEmptyPayload)
+	s.Assert().Nil(objectInfo)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_GetStream_ExpectOK() {
+	// GIVEN
+	key := "some-stream"
+	data := []byte("some-data")
+	savedInfo, err := s.persistentStorage.Save(key, data)
+	s.Require().NoError(err)
+
+	_, err = s.persistentStorage.Save(key, []byte("some-other-data"))
+	s.Require().NoError(err)
+
+	var transferred int64
+
+	// WHEN
+	reader, objectInfo, err := s.persistentStorage.GetStream(context.Background(), key, savedInfo.VersionID,
+		persistentstorage.WithProgress(func(t, _ int64) { transferred = t }))
+
+	// THEN
+	s.Require().NoError(err)
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	s.Require().NoError(err)
+	s.Assert().Equal(data, content)
+	s.Assert().Equal(savedInfo.VersionID, objectInfo.VersionID)
+	s.Assert().Equal(int64(len(data)), transferred)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_GetStreamFromOffset_ExpectRemainingContent() {
+	// GIVEN
+	key := "some-stream"
+	_, err := s.persistentStorage.Save(key, []byte("some-data"))
+	s.Require().NoError(err)
+
+	// WHEN
+	reader, _, err := s.persistentStorage.GetStream(context.Background(), key, "", persistentstorage.WithOffset(5))
+
+	// THEN
+	s.Require().NoError(err)
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	s.Require().NoError(err)
+	s.Assert().Equal("data", string(content))
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_GetStreamOfMissingObject_ExpectError() {
+	// WHEN
+	reader, _, err := s.persistentStorage.GetStream(context.Background(), "missing-object", "")
+
+	// THEN
+	s.Assert().Error(err)
+	s.Assert().Nil(reader)
+}
@@ -0,0 +1,193 @@
+//go:build integration
+
+package persistentstorage_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+
+	"github.com/konstellation-io/kai-gosdk/internal/errors"
+	persistentstorage "github.com/konstellation-io/kai-gosdk/sdk/persistent-storage"
+)
+
+func (s *SdkPersistentStorageTestSuite) getTags(key string) map[string]string {
+	objectTags, err := s.client.GetObjectTagging(context.Background(), s.persistentStorageBucket, key,
+		minio.GetObjectTaggingOptions{})
+	s.Require().NoError(err)
+
+	return objectTags.ToMap()
+}
+
+func (s *SdkPersistentStorageTestSuite) getLifecycleRule(id string) *lifecycle.Rule {
+	lc, err := s.client.GetBucketLifecycle(context.Background(), s.persistentStorageBucket)
+	s.Require().NoError(err)
+
+	for i := range lc.Rules {
+		if lc.Rules[i].ID == id {
+			return &lc.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveObjectsWithTTL_ExpectSharedLifecycleRule() {
+	// GIVEN
+	data := []byte("some-data")
+	ttlDays := 5
+
+	// WHEN
+	firstInfo, err := s.persistentStorage.Save("some-object", data, ttlDays)
+	s.Require().NoError(err)
+	_, err = s.persistentStorage.Save("some-other-object", data, ttlDays)
+	s.Require().NoError(err)
+
+	// THEN
+	s.Assert().WithinDuration(time.Now().Add(6*24*time.Hour), firstInfo.ExpiresIn, 24*time.Hour)
+	s.Assert().Equal("5", s.getTags("some-object")["kai-ttl-days"])
+
+	rule := s.getLifecycleRule("kai-ttl-5d")
+	s.Require().NotNil(rule)
+	s.Assert().Equal("kai-ttl-days", rule.RuleFilter.Tag.Key)
+	s.Assert().Equal("5", rule.RuleFilter.Tag.Value)
+	s.Assert().Equal(lifecycle.ExpirationDays(5), rule.Expiration.Days)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveObjectWithTTL_ExpectRuleOfEveryClass() {
+	// WHEN
+	_, err := s.persistentStorage.Save("some-object", []byte("some-data"), 5)
+	s.Require().NoError(err)
+
+	// THEN
+	lc, err := s.client.GetBucketLifecycle(context.Background(), s.persistentStorageBucket)
+	s.Require().NoError(err)
+	s.Assert().Len(lc.Rules, 20)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveObjectWithTTLBetweenClasses_ExpectRoundedUp() {
+	// WHEN
+	objectInfo, err := s.persistentStorage.Save("some-object", []byte("some-data"), 4)
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().WithinDuration(time.Now().Add(6*24*time.Hour), objectInfo.ExpiresIn, 24*time.Hour)
+	s.Assert().Equal("5", s.getTags("some-object")["kai-ttl-days"])
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SaveObjectWithTTLAboveClasses_ExpectLargestClass() {
+	// WHEN
+	_, err := s.persistentStorage.Save("some-object", []byte("some-data"), 5000)
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().Equal("3650", s.getTags("some-object")["kai-ttl-days"])
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_LegacyLifecycleRules_ExpectDropped() {
+	// GIVEN
+	lc := lifecycle.NewConfiguration()
+	lc.Rules = []lifecycle.Rule{
+		{
+			ID:         "ttl-some-object",
+			Status:     minio.Enabled,
+			RuleFilter: lifecycle.Filter{Prefix: "some-object"},
+			Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(5)},
+		},
+		{
+			ID:         "other-rule",
+			Status:     minio.Enabled,
+			RuleFilter: lifecycle.Filter{Prefix: "other/"},
+			Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(5)},
+		},
+	}
+
+	err := s.client.SetBucketLifecycle(context.Background(), s.persistentStorageBucket, lc)
+	s.Require().NoError(err)
+
+	// WHEN
+	_, err = s.persistentStorage.Save("some-object", []byte("some-data"), 5)
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().Nil(s.getLifecycleRule("ttl-some-object"))
+	s.Assert().NotNil(s.getLifecycleRule("other-rule"))
+	s.Assert().NotNil(s.getLifecycleRule("kai-ttl-5d"))
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_LifecycleOverwritten_ExpectRulesRestored() {
+	// GIVEN
+	_, err := s.persistentStorage.Save("some-object", []byte("some-data"), 5)
+	s.Require().NoError(err)
+
+	err = s.client.SetBucketLifecycle(context.Background(), s.persistentStorageBucket, lifecycle.NewConfiguration())
+	s.Require().NoError(err)
+
+	persistentstorage.ExpireTTLRulesCheck(s.persistentStorage)
+
+	// WHEN
+	_, err = s.persistentStorage.Save("some-other-object", []byte("some-data"), 5)
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().NotNil(s.getLifecycleRule("kai-ttl-5d"))
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_GetObjectWithTTL_ExpectExpiration() {
+	// GIVEN
+	key := "some-object"
+	savedInfo, err := s.persistentStorage.Save(key, []byte("some-data"), 5)
+	s.Require().NoError(err)
+
+	// WHEN
+	object, err := s.persistentStorage.Get(key)
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().Equal(savedInfo.ExpiresIn, object.ExpiresIn)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SetTTL_ExpectTTLChanged() {
+	// GIVEN
+	key := "some-object"
+	_, err := s.persistentStorage.Save(key, []byte("some-data"), 5)
+	s.Require().NoError(err)
+
+	// WHEN
+	objectInfo, err := s.persistentStorage.SetTTL(context.Background(), key, "", 30)
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().WithinDuration(time.Now().Add(31*24*time.Hour), objectInfo.ExpiresIn, 24*time.Hour)
+	s.Assert().Equal("30", s.getTags(key)["kai-ttl-days"])
+	s.Assert().NotNil(s.getLifecycleRule("kai-ttl-30d"))
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_SetInvalidTTL_ExpectError() {
+	// WHEN
+	objectInfo, err := s.persistentStorage.SetTTL(context.Background(), "some-object", "", 0)
+
+	// THEN
+	s.Assert().ErrorIs(err, errors.ErrInvalidTTL)
+	s.Assert().Nil(objectInfo)
+}
+
+func (s *SdkPersistentStorageTestSuite) TestPersistentStorage_RemoveTTL_ExpectNoExpiration() {
+	// GIVEN
+	key := "some-object"
+	_, err := s.persistentStorage.Save(key, []byte("some-data"), 5)
+	s.Require().NoError(err)
+
+	// WHEN
+	err = s.persistentStorage.RemoveTTL(context.Background(), key, "")
+
+	// THEN
+	s.Require().NoError(err)
+	s.Assert().Empty(s.getTags(key))
+
+	object, err := s.persistentStorage.Get(key)
+	s.Require().NoError(err)
+	s.Assert().True(object.ExpiresIn.IsZero())
+}
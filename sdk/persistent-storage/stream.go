@@ -0,0 +1,200 @@
+package persistentstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+
+	utilErrors "github.com/konstellation-io/kai-gosdk/internal/errors"
+)
+
+const _defaultStreamPartSize = 16 << 20
+
+// SaveStream stores the content of the reader without loading it in memory, uploading it in parts if large.
+// The size must be -1 if unknown.
+func (ps PersistentStorage) SaveStream(ctx context.Context, key string, reader io.Reader, size int64,
+	opts ...ObjectOption,
+) (*ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if reader == nil || size == 0 {
+		return nil, utilErrors.ErrEmptyPayload
+	}
+
+	options := newObjectOptions(opts)
+
+	if size < 0 && options.partSize == 0 {
+		options.partSize = _defaultStreamPartSize
+	}
+
+	return ps.putObject(ctx, key, reader, size, options)
+}
+
+// GetStream returns a reader of the object and its info. Reads failing midway are resumed from the last byte
+// read, of the same version of the object. The reader must be closed.
+func (ps PersistentStorage) GetStream(ctx context.Context, key, version string,
+	opts ...ObjectOption,
+) (io.ReadCloser, ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	options := newObjectOptions(opts)
+
+	object, err := ps.openObject(ctx, key, version, "", options.offset)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	// Stat sends the request, so a missing object is reported here instead of on the first read.
+	stats, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, ObjectInfo{}, fmt.Errorf("error retrieving object from the persistent storage: %w", err)
+	}
+
+	objectInfo, err := ps.getObjectInfo(ctx, key, stats)
+	if err != nil {
+		object.Close()
+		return nil, ObjectInfo{}, err
+	}
+
+	ps.logger.WithName(_persistentStorageLoggerName).V(1).
+		Info(fmt.Sprintf("Object %s stream opened from persistent storage", key))
+
+	// Resumed reads ask for the same version, or the same content if the bucket is not versioned.
+	etag := ""
+	if stats.VersionID == "" {
+		etag = stats.ETag
+	}
+
+	reader := &resumableReader{
+		ctx:     ctx,
+		reader:  object,
+		offset:  options.offset,
+		total:   options.offset + stats.Size,
+		retries: options.retries,
+		open: func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+			return ps.openObject(ctx, key, stats.VersionID, etag, offset)
+		},
+		progress: options.progress,
+	}
+
+	return reader, *objectInfo, nil
+}
+
+func (ps PersistentStorage) openObject(ctx context.Context, key, version, etag string,
+	offset int64,
+) (*minio.Object, error) {
+	opts := minio.GetObjectOptions{VersionID: version}
+
+	if etag != "" {
+		if err := opts.SetMatchETag(etag); err != nil {
+			return nil, err
+		}
+	}
+
+	if offset > 0 {
+		if err := opts.SetRange(offset, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	object, err := ps.storageClient.GetObject(ctx, ps.storageBucket, key, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving object from the persistent storage: %w", err)
+	}
+
+	return object, nil
+}
+
+// resumableReader reopens the object from the last byte read when a read fails.
+type resumableReader struct {
+	ctx      context.Context
+	reader   io.ReadCloser
+	offset   int64
+	total    int64
+	retries  int
+	failures int
+	open     func(ctx context.Context, offset int64) (io.ReadCloser, error)
+	progress ProgressFunc
+}
+
+func (r *resumableReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.reader.Read(p)
+		r.offset += int64(n)
+
+		if n > 0 {
+			r.failures = 0
+
+			if r.progress != nil {
+				r.progress(r.offset, r.total)
+			}
+		}
+
+		if err == nil || errors.Is(err, io.EOF) || r.failures >= r.retries || r.ctx.Err() != nil {
+			return n, err
+		}
+
+		r.failures++
+		r.reader.Close()
+
+		reader, openErr := r.open(r.ctx, r.offset)
+		if openErr != nil {
+			r.reader = io.NopCloser(errReader{openErr})
+			return n, openErr
+		}
+
+		r.reader = reader
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (r *resumableReader) Close() error {
+	return r.reader.Close()
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// progressReader reports the bytes uploaded, as the minio client reads each part uploaded from it.
+type progressReader struct {
+	mu          sync.Mutex
+	progress    ProgressFunc
+	transferred int64
+	total       int64
+}
+
+func newProgressReader(progress ProgressFunc, total int64) io.Reader {
+	if progress == nil {
+		return nil
+	}
+
+	return &progressReader{progress: progress, total: total}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	// Parts may be uploaded concurrently, so the progress is reported in order.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.transferred += int64(len(b))
+	p.progress(p.transferred, p.total)
+
+	return len(b), nil
+}
@@ -0,0 +1,305 @@
+package persistentstorage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/minio/minio-go/v7/pkg/tags"
+
+	"github.com/konstellation-io/kai-gosdk/internal/errors"
+)
+
+const (
+	_ttlTag                     = "kai-ttl-days"
+	_legacyTTLRulePrefix        = "ttl-"
+	_ttlRuleAttempts            = 3
+	_ttlRulesCheckInterval      = time.Minute
+	_noSuchLifecycleConfigError = "NoSuchLifecycleConfiguration"
+	_day                        = 24 * time.Hour
+)
+
+// ttlClasses are the TTLs objects can expire with, each one with its lifecycle rule. Other TTLs are rounded up to
+// the next class, or down to the largest one, so the number of rules in the bucket lifecycle is bounded.
+var ttlClasses = []int{ //nolint:gochecknoglobals // read-only list of TTL classes
+	1, 2, 3, 5, 7, 10, 14, 21, 30, 45, 60, 90, 120, 180, 270, 365, 730, 1095, 1825, 3650,
+}
+
+// ttlRules remembers when the lifecycle rules were last checked, so the bucket lifecycle is not read on every save.
+type ttlRules struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+}
+
+func newTTLRules() *ttlRules {
+	return &ttlRules{}
+}
+
+// checked returns whether the rules were checked recently, always false without rules to remember it.
+func (r *ttlRules) checked() bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return time.Since(r.checkedAt) < _ttlRulesCheckInterval
+}
+
+func (r *ttlRules) setChecked() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkedAt = time.Now()
+}
+
+// ttlClass returns the TTL class the given days are rounded up to, the largest one for longer TTLs.
+func ttlClass(ttlDays int) (int, error) {
+	if ttlDays <= 0 {
+		return 0, errors.ErrInvalidTTL
+	}
+
+	for _, class := range ttlClasses {
+		if ttlDays <= class {
+			return class, nil
+		}
+	}
+
+	return ttlClasses[len(ttlClasses)-1], nil
+}
+
+// SetTTL sets the days after which the object expires, counted from when it was saved and rounded up to the next
+// TTL class. Only the current version of an object expires, so the version should be empty unless it is the
+// current one.
+func (ps PersistentStorage) SetTTL(ctx context.Context, key, version string, ttlDays int) (*ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	ttlDays, err := ttlClass(ttlDays)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.ensureTTLRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error adding lifecycle deletion rules: %w", err)
+	}
+
+	objectTags, err := ps.getObjectTags(ctx, key, version)
+	if err != nil {
+		return nil, err
+	}
+
+	objectTags[_ttlTag] = strconv.Itoa(ttlDays)
+
+	err = ps.putObjectTags(ctx, key, version, objectTags)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := ps.storageClient.StatObject(ctx, ps.storageBucket, key, minio.StatObjectOptions{VersionID: version})
+	if err != nil {
+		return nil, fmt.Errorf("error getting object stats from the persistent storage: %w", err)
+	}
+
+	ps.logger.WithName(_persistentStorageLoggerName).V(1).
+		Info(fmt.Sprintf("Object %s set to expire in %d days", key, ttlDays))
+
+	return newObjectInfo(key, stats, statMetadata(stats.UserMetadata), objectTags), nil
+}
+
+// RemoveTTL keeps the object until deleted.
+func (ps PersistentStorage) RemoveTTL(ctx context.Context, key, version string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	objectTags, err := ps.getObjectTags(ctx, key, version)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := objectTags[_ttlTag]; !ok {
+		return nil
+	}
+
+	delete(objectTags, _ttlTag)
+
+	if len(objectTags) == 0 {
+		err = ps.storageClient.RemoveObjectTagging(ctx, ps.storageBucket, key,
+			minio.RemoveObjectTaggingOptions{VersionID: version})
+	} else {
+		err = ps.putObjectTags(ctx, key, version, objectTags)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error removing object TTL from the persistent storage: %w", err)
+	}
+
+	ps.logger.WithName(_persistentStorageLoggerName).V(1).
+		Info(fmt.Sprintf("Object %s TTL removed", key))
+
+	return nil
+}
+
+func (ps PersistentStorage) getObjectTags(ctx context.Context, key, version string) (map[string]string, error) {
+	objectTags, err := ps.storageClient.GetObjectTagging(ctx, ps.storageBucket, key,
+		minio.GetObjectTaggingOptions{VersionID: version})
+	if err != nil {
+		return nil, fmt.Errorf("error getting object tags from the persistent storage: %w", err)
+	}
+
+	return objectTags.ToMap(), nil
+}
+
+func (ps PersistentStorage) putObjectTags(ctx context.Context, key, version string,
+	objectTags map[string]string,
+) error {
+	newTags, err := tags.NewTags(objectTags, true)
+	if err != nil {
+		return fmt.Errorf("invalid object tags: %w", err)
+	}
+
+	err = ps.storageClient.PutObjectTagging(ctx, ps.storageBucket, key, newTags,
+		minio.PutObjectTaggingOptions{VersionID: version})
+	if err != nil {
+		return fmt.Errorf("error setting object tags in the persistent storage: %w", err)
+	}
+
+	return nil
+}
+
+// ensureTTLRules adds the lifecycle rules expiring the objects of every TTL class, if missing, and drops the legacy
+// rules added for each object saved with a TTL. Every process adds the same rules, so concurrent changes of the
+// lifecycle do not drop them, and they are checked again after being added and periodically, in case the lifecycle
+// is overwritten by other means. Concurrent saves may check the rules at the same time, as the changes are the same.
+func (ps PersistentStorage) ensureTTLRules(ctx context.Context) error {
+	if ps.ttlRules.checked() {
+		return nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		lc, err := ps.getBucketLifecycle(ctx)
+		if err != nil {
+			return err
+		}
+
+		rules, legacy := withoutLegacyTTLRules(lc.Rules)
+		missing := missingTTLRules(lc)
+
+		if len(missing) == 0 && legacy == 0 {
+			ps.ttlRules.setChecked()
+			return nil
+		}
+
+		if attempt == _ttlRuleAttempts {
+			return fmt.Errorf("lifecycle rules overwritten by concurrent changes after %d attempts", attempt)
+		}
+
+		if legacy > 0 {
+			ps.logger.WithName(_persistentStorageLoggerName).
+				Info(fmt.Sprintf("Dropping %d legacy lifecycle rules, objects saved with them must set their TTL again",
+					legacy))
+		}
+
+		lc.Rules = append(rules, missing...)
+
+		err = ps.storageClient.SetBucketLifecycle(ctx, ps.storageBucket, lc)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (ps PersistentStorage) getBucketLifecycle(ctx context.Context) (*lifecycle.Configuration, error) {
+	lc, err := ps.storageClient.GetBucketLifecycle(ctx, ps.storageBucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == _noSuchLifecycleConfigError {
+			return lifecycle.NewConfiguration(), nil
+		}
+
+		return nil, err
+	}
+
+	return lc, nil
+}
+
+func ttlRule(ttlDays int) lifecycle.Rule {
+	return lifecycle.Rule{
+		ID:     fmt.Sprintf("kai-ttl-%dd", ttlDays),
+		Status: minio.Enabled,
+		RuleFilter: lifecycle.Filter{
+			Tag: lifecycle.Tag{Key: _ttlTag, Value: strconv.Itoa(ttlDays)},
+		},
+		Expiration: lifecycle.Expiration{
+			Days: lifecycle.ExpirationDays(ttlDays),
+		},
+	}
+}
+
+// withoutLegacyTTLRules returns the rules but the legacy ones, expiring the objects with a given prefix, along with
+// how many were dropped.
+func withoutLegacyTTLRules(rules []lifecycle.Rule) ([]lifecycle.Rule, int) {
+	kept := make([]lifecycle.Rule, 0, len(rules))
+
+	for _, r := range rules {
+		if !strings.HasPrefix(r.ID, _legacyTTLRulePrefix) {
+			kept = append(kept, r)
+		}
+	}
+
+	return kept, len(rules) - len(kept)
+}
+
+func missingTTLRules(lc *lifecycle.Configuration) []lifecycle.Rule {
+	existing := make(map[string]bool, len(lc.Rules))
+	for _, r := range lc.Rules {
+		existing[r.ID] = true
+	}
+
+	var missing []lifecycle.Rule
+
+	for _, class := range ttlClasses {
+		rule := ttlRule(class)
+		if !existing[rule.ID] {
+			missing = append(missing, rule)
+		}
+	}
+
+	return missing
+}
+
+// objectExpiration returns when an object with the given tags expires, or the expiration reported by the storage
+// if it has no TTL tag.
+func objectExpiration(lastModified time.Time, objectTags map[string]string, reported time.Time) time.Time {
+	ttlDays, err := strconv.Atoi(objectTags[_ttlTag])
+	if err != nil || ttlDays <= 0 {
+		return reported
+	}
+
+	return expirationTime(lastModified, ttlDays)
+}
+
+// expirationTime follows the lifecycle rules of S3: objects expire at the midnight UTC following the given days
+// after they were saved.
+func expirationTime(created time.Time, ttlDays int) time.Time {
+	expiration := created.UTC().Add(time.Duration(ttlDays) * _day)
+
+	midnight := expiration.Truncate(_day)
+	if midnight.Before(expiration) {
+		midnight = midnight.Add(_day)
+	}
+
+	return midnight
+}